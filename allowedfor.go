@@ -0,0 +1,222 @@
+package notany
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strconv"
+	"strings"
+
+	"github.com/qawatake/notany/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+)
+
+// allowedForFact is exported for a type annotated with //notany:allowed-for.
+type allowedForFact struct {
+	Targets []allowedFor
+}
+
+// allowedFor is a target for which the annotated type is allowed.
+type allowedFor struct {
+	// Qualifier is the package path of the function, or the package path followed by the receiver type name for a method.
+	Qualifier string
+	// Name is the name of the function or the method.
+	Name string
+	// ArgPos is -1 if the type is allowed for all the checked arguments of the function.
+	ArgPos int
+}
+
+func (*allowedForFact) AFact() {}
+
+func (f *allowedForFact) String() string {
+	ss := make([]string, 0, len(f.Targets))
+	for _, t := range f.Targets {
+		ss = append(ss, t.String())
+	}
+	return "allowed-for(" + strings.Join(ss, " ") + ")"
+}
+
+func (a allowedFor) String() string {
+	s := a.Qualifier + "." + a.Name
+	if a.ArgPos >= 0 {
+		s += ":" + strconv.Itoa(a.ArgPos)
+	}
+	return s
+}
+
+// exportAllowedForFacts exports facts for the types annotated with //notany:allowed-for in the package.
+func exportAllowedForFacts(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				groups := []*ast.CommentGroup{ts.Doc, ts.Comment}
+				if !gd.Lparen.IsValid() {
+					groups = append(groups, gd.Doc)
+				}
+				fact := new(allowedForFact)
+				for _, cg := range groups {
+					if cg == nil {
+						continue
+					}
+					for _, c := range cg.List {
+						args, ok := parseDirective(c.Text, "allowed-for")
+						if !ok {
+							continue
+						}
+						for _, arg := range args {
+							t, err := parseAllowedFor(arg)
+							if err == nil {
+								err = resolveAllowedFor(pass.Pkg, t)
+							}
+							if err != nil {
+								pass.Reportf(c.Pos(), "invalid target %s in //notany:allowed-for: %v", arg, err)
+								continue
+							}
+							fact.Targets = append(fact.Targets, t)
+						}
+					}
+				}
+				if len(fact.Targets) == 0 {
+					continue
+				}
+				if obj, ok := pass.TypesInfo.Defs[ts.Name].(*types.TypeName); ok {
+					pass.ExportObjectFact(obj, fact)
+				}
+			}
+		}
+	}
+}
+
+// parseAllowedFor parses the target in the form pkg/path.FuncName[:argpos] or pkg/path.Recv.Method[:argpos].
+// It is split at the last dot, so that package paths containing dots such as gopkg.in/yaml.v3 are accepted.
+func parseAllowedFor(s string) (allowedFor, error) {
+	ret := allowedFor{ArgPos: -1}
+	if i := strings.LastIndex(s, ":"); i >= 0 {
+		pos, err := strconv.Atoi(s[i+1:])
+		if err != nil || pos < 0 {
+			return allowedFor{}, fmt.Errorf("invalid arg position %q", s[i+1:])
+		}
+		ret.ArgPos = pos
+		s = s[:i]
+	}
+	dot := strings.LastIndex(s, ".")
+	if dot <= 0 || dot == len(s)-1 {
+		return allowedFor{}, errors.New("want pkg/path.FuncName[:ArgPos] or pkg/path.Recv.Method[:ArgPos]")
+	}
+	ret.Qualifier = s[:dot]
+	ret.Name = s[dot+1:]
+	return ret, nil
+}
+
+// resolveAllowedFor checks that a names a function or a method if its package is reachable from pkg.
+// If the package is not reachable, a is kept as it is and matched with the targets by name.
+func resolveAllowedFor(pkg *types.Package, a allowedFor) error {
+	if p := analysisutil.PackageOfBFS(pkg, a.Qualifier); p != nil {
+		if _, ok := p.Scope().Lookup(a.Name).(*types.Func); !ok {
+			return fmt.Errorf("function %s not found in %s", a.Name, p.Path())
+		}
+		return nil
+	}
+	dot := strings.LastIndex(a.Qualifier, ".")
+	if dot < 0 {
+		return nil
+	}
+	p := analysisutil.PackageOfBFS(pkg, a.Qualifier[:dot])
+	if p == nil {
+		return nil
+	}
+	recv, ok := p.Scope().Lookup(a.Qualifier[dot+1:]).(*types.TypeName)
+	if !ok {
+		return fmt.Errorf("type %s not found in %s", a.Qualifier[dot+1:], p.Path())
+	}
+	obj, _, _ := types.LookupFieldOrMethod(types.NewPointer(recv.Type()), false, p, a.Name)
+	if _, ok := obj.(*types.Func); !ok {
+		return fmt.Errorf("method %s not found in %s", a.Name, recv.Type())
+	}
+	return nil
+}
+
+// addAllowedFor returns copies of the targets with the types annotated with //notany:allowed-for added to their allowed types.
+// The targets themselves are not modified.
+func addAllowedFor(pass *analysis.Pass, targets []*analysisTarget) []*analysisTarget {
+	ret := make([]*analysisTarget, 0, len(targets))
+	for _, t := range targets {
+		if t.AllowedFor && t.Func != nil {
+			c := *t
+			c.Allowed = t.Allowed.Clone()
+			t = &c
+		}
+		ret = append(ret, t)
+	}
+	for _, f := range pass.AllObjectFacts() {
+		af, ok := f.Fact.(*allowedForFact)
+		if !ok {
+			continue
+		}
+		obj, ok := f.Object.(*types.TypeName)
+		if !ok {
+			continue
+		}
+		for _, t := range ret {
+			if !t.AllowedFor || t.Func == nil {
+				continue
+			}
+			// wrappers accept the types annotated for the targets they wrap.
+			target := t.Wrapped
+			if target == nil {
+				target = allowedForOf(t.Func, t.ArgPos)
+			}
+			for _, a := range af.Targets {
+				if a.matches(target) {
+					t.Allowed.Types[obj.Type()] = struct{}{}
+				}
+			}
+		}
+	}
+	return ret
+}
+
+// allowedForOf returns the target of the argument at argPos of fn.
+func allowedForOf(fn *types.Func, argPos int) *allowedFor {
+	ret := &allowedFor{Name: fn.Name(), ArgPos: argPos}
+	if fn.Pkg() == nil {
+		return ret
+	}
+	ret.Qualifier = fn.Pkg().Path()
+	if sig, ok := fn.Type().(*types.Signature); ok && sig.Recv() != nil {
+		name, _, _ := strings.Cut(funcNameOf(fn), ".")
+		ret.Qualifier += "." + name
+	}
+	return ret
+}
+
+// matches reports whether a denotes the function of target and covers its argument.
+func (a allowedFor) matches(target *allowedFor) bool {
+	if a.Qualifier != target.Qualifier || a.Name != target.Name {
+		return false
+	}
+	return a.ArgPos < 0 || a.ArgPos == target.ArgPos
+}
+
+// funcNameOf returns the name of fn in the form of Target.FuncName, that is, Func or Recv.Method.
+func funcNameOf(fn *types.Func) string {
+	sig, ok := fn.Type().(*types.Signature)
+	if !ok || sig.Recv() == nil {
+		return fn.Name()
+	}
+	recv := sig.Recv().Type()
+	if ptr, ok := recv.(*types.Pointer); ok {
+		recv = ptr.Elem()
+	}
+	if named, ok := recv.(*types.Named); ok {
+		return named.Obj().Name() + "." + fn.Name()
+	}
+	return fn.Name()
+}
@@ -0,0 +1,75 @@
+package notany
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// consistency records the first type passed to each receiver of the consistent targets in the package.
+// The types are shared among the targets called on the same receiver, such as Store and Swap of atomic.Value.
+type consistency struct {
+	// first is keyed by the field or the package-level variable on which the methods are called.
+	first map[*types.Var]consistentStore
+}
+
+type consistentStore struct {
+	Pos  token.Pos
+	Type types.Type
+}
+
+func newConsistency() *consistency {
+	return &consistency{
+		first: make(map[*types.Var]consistentStore),
+	}
+}
+
+// Inconsistent returns the arguments of the call n whose types differ from those of the first calls on the same receiver.
+// The calls must be visited in the order of their positions.
+func (c *consistency) Inconsistent(pass *analysis.Pass, targets []*analysisTarget, n *ast.CallExpr) []*notAllowed {
+	sel, ok := n.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return nil
+	}
+	obj, ok := pass.TypesInfo.ObjectOf(sel.Sel).(*types.Func)
+	if !ok {
+		return nil
+	}
+	recv := varOf(pass, sel.X)
+	if recv == nil || (!recv.IsField() && recv.Parent() != recv.Pkg().Scope()) {
+		// local variables are not subject to it.
+		return nil
+	}
+	args := callArgsOf(pass, n)
+	var ret []*notAllowed
+	for _, t := range targets {
+		if t.Func != obj || !t.Consistent || t.ArgPos >= len(args) {
+			continue
+		}
+		arg := args[t.ArgPos]
+		if arg.Type == nil || isNil(pass, arg.Expr) || types.IsInterface(arg.Type) {
+			// the dynamic type is unknown.
+			continue
+		}
+		first, ok := c.first[recv]
+		if !ok {
+			c.first[recv] = consistentStore{Pos: arg.Expr.Pos(), Type: arg.Type}
+			continue
+		}
+		if types.Identical(first.Type, arg.Type) {
+			continue
+		}
+		ret = append(ret, &notAllowed{
+			ArgExpr:   arg.Expr,
+			ArgType:   arg.Type,
+			ArgPos:    t.ArgPos,
+			Func:      obj,
+			Var:       recv,
+			First:     first.Pos,
+			FirstType: first.Type,
+		})
+	}
+	return ret
+}
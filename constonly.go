@@ -0,0 +1,42 @@
+package notany
+
+import (
+	"go/ast"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// nonConstToBeReported returns the arguments of the call n which must be compile-time constants but are not.
+// It is independent of toBeReported so that both can be reported for the same argument.
+func nonConstToBeReported(pass *analysis.Pass, targets []*analysisTarget, flow *flow, n *ast.CallExpr) []*notAllowed {
+	obj := calleeOf(pass, n)
+	if obj == nil {
+		return nil
+	}
+	sig, _ := obj.Type().(*types.Signature)
+	args := callArgsOf(pass, n)
+	var ret []*notAllowed
+	for _, t := range targets {
+		if t.Func != obj || !t.ConstOnly || flow.Forwarded(n, t) {
+			continue
+		}
+		end := t.ArgPos + 1
+		if isVariadicParam(sig, t.ArgPos) {
+			end = len(args)
+		}
+		for p := t.ArgPos; p < end && p < len(args); p++ {
+			tv := pass.TypesInfo.Types[args[p].Expr]
+			if tv.Value != nil || (tv.IsNil() && t.AllowNil) {
+				continue
+			}
+			ret = append(ret, &notAllowed{
+				ArgExpr: args[p].Expr,
+				ArgType: args[p].Type,
+				ArgPos:  p,
+				Func:    obj,
+			})
+		}
+	}
+	return ret
+}
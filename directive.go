@@ -0,0 +1,99 @@
+package notany
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+const directivePrefix = "//notany:"
+
+// directive returns the arguments of the directive //notany:<name> written on the line of pos,
+// or on its own line just above the line of pos.
+// ok is false if the directive is not found.
+func directive(pass *analysis.Pass, pos token.Pos, name string) (args []string, ok bool) {
+	file := fileOf(pass, pos)
+	if file == nil {
+		return nil, false
+	}
+	p := pass.Fset.Position(pos)
+	for _, cg := range file.Comments {
+		for _, c := range cg.List {
+			cp := pass.Fset.Position(c.Slash)
+			if cp.Line != p.Line && cp.Line != p.Line-1 {
+				continue
+			}
+			args, ok := parseDirective(c.Text, name)
+			if !ok {
+				continue
+			}
+			if cp.Line == p.Line-1 && trailing(pass, file, c) {
+				// the directive belongs to the code on the line above.
+				continue
+			}
+			return args, true
+		}
+	}
+	return nil, false
+}
+
+// trailing reports whether the comment c follows code on the same line.
+func trailing(pass *analysis.Pass, file *ast.File, c *ast.Comment) bool {
+	line := pass.Fset.Position(c.Slash).Line
+	found := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		if n == nil || found {
+			return false
+		}
+		switch n.(type) {
+		case *ast.File:
+			return true
+		case *ast.Comment, *ast.CommentGroup:
+			return false
+		}
+		if pass.Fset.Position(n.Pos()).Line > line || pass.Fset.Position(n.End()).Line < line {
+			// n does not span the line.
+			return false
+		}
+		if n.End() <= c.Slash && pass.Fset.Position(n.End()).Line == line {
+			found = true
+			return false
+		}
+		return true
+	})
+	return found
+}
+
+// declDirective returns the arguments and the position of the directive //notany:<name> in the comment groups of a declaration.
+// ok is false if the directive is not found.
+func declDirective(groups []*ast.CommentGroup, name string) (args []string, pos token.Pos, ok bool) {
+	for _, cg := range groups {
+		if cg == nil {
+			continue
+		}
+		for _, c := range cg.List {
+			if args, ok := parseDirective(c.Text, name); ok {
+				return args, c.Slash, true
+			}
+		}
+	}
+	return nil, token.NoPos, false
+}
+
+// parseDirective parses the comment text in the form //notany:<name> arg1 arg2 ...
+// Arguments end at the next comment marker.
+func parseDirective(text, name string) (args []string, ok bool) {
+	rest, found := strings.CutPrefix(text, directivePrefix+name)
+	if !found {
+		return nil, false
+	}
+	if rest != "" && rest[0] != ' ' && rest[0] != '\t' {
+		return nil, false
+	}
+	if i := strings.Index(rest, "//"); i >= 0 {
+		rest = rest[:i]
+	}
+	return strings.Fields(rest), true
+}
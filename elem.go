@@ -0,0 +1,492 @@
+package notany
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// elemNotAllowed returns the first element of the container arg of the call which is not allowed by t.
+// argType is the type of the container.
+// If nil is returned, it means that all the elements are allowed.
+func elemNotAllowed(pass *analysis.Pass, t *analysisTarget, call *ast.CallExpr, arg ast.Expr, argType types.Type) *notAllowed {
+	elemType := elemTypeOf(argType)
+	if elemType == nil {
+		return nil
+	}
+	if _, ok := elemType.Underlying().(*types.Interface); !ok && !t.Allow(elemType) {
+		// the static element type is the dynamic one.
+		return &notAllowed{
+			ArgExpr: arg,
+			ArgType: elemType,
+			Elem:    true,
+		}
+	}
+	if _, ok := elemType.Underlying().(*types.Interface); !ok && t.AllowValue(elemType, nil) {
+		// the values need not be checked.
+		return nil
+	}
+	exprs, ok := elemExprs(pass, arg)
+	if !ok {
+		return untracedNotAllowed(pass, t, call, arg, elemType)
+	}
+	for _, e := range exprs {
+		elem := callArg{Expr: e, Type: pass.TypesInfo.TypeOf(e)}
+		if isZeroElem(e) {
+			elem.Type = elemType
+			if types.IsInterface(elemType) {
+				elem.Type = types.Typ[types.UntypedNil]
+			}
+		}
+		if result := checkArg(pass, t, call, elem, t.ArgPos); result != nil {
+			result.Elem = true
+			return result
+		}
+	}
+	return nil
+}
+
+// untracedNotAllowed returns the result for the container arg of the call whose elements cannot be traced
+// according to the Untraced policy of t.
+func untracedNotAllowed(pass *analysis.Pass, t *analysisTarget, call *ast.CallExpr, arg ast.Expr, elemType types.Type) *notAllowed {
+	switch t.Untraced {
+	case UntracedTrust:
+		return nil
+	case UntracedAnnotated:
+		if _, ok := directive(pass, call.Pos(), "trust"); ok {
+			return nil
+		}
+	}
+	if elemType == nil {
+		// the element type is unknown, e.g. the container is of a type parameter without a core type.
+		return &notAllowed{
+			ArgExpr: arg,
+			ArgType: pass.TypesInfo.TypeOf(arg),
+			Elem:    true,
+		}
+	}
+	// fall back to the static element type.
+	if t.Allow(elemType) && t.AllowValue(elemType, nil) {
+		return nil
+	}
+	return &notAllowed{
+		ArgExpr:  arg,
+		ArgType:  elemType,
+		Elem:     true,
+		BadValue: t.Allow(elemType),
+	}
+}
+
+// elemTypeOf returns the element type of a slice, an array, a pointer to an array, or the value type of a map.
+// A type parameter is regarded as its core type.
+// If typ is not a container, nil is returned.
+func elemTypeOf(typ types.Type) types.Type {
+	if typ == nil {
+		return nil
+	}
+	switch t := coreType(typ).(type) {
+	case *types.Slice:
+		return t.Elem()
+	case *types.Array:
+		return t.Elem()
+	case *types.Map:
+		return t.Elem()
+	case *types.Pointer:
+		if a, ok := t.Elem().Underlying().(*types.Array); ok {
+			return a.Elem()
+		}
+	}
+	return nil
+}
+
+// coreType returns the underlying type of typ.
+// If typ is a type parameter, the underlying type shared by all the types in its type set is returned,
+// or nil if there is no such type.
+func coreType(typ types.Type) types.Type {
+	tp, ok := typ.(*types.TypeParam)
+	if !ok {
+		return typ.Underlying()
+	}
+	iface, ok := tp.Constraint().Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+	return coreTypeOfTerms(iface)
+}
+
+// coreTypeOfTerms returns the underlying type shared by all the type terms of the constraint iface, or nil if there is no such type.
+func coreTypeOfTerms(iface *types.Interface) types.Type {
+	var ret types.Type
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		var terms []types.Type
+		switch e := iface.EmbeddedType(i).(type) {
+		case *types.Union:
+			for j := 0; j < e.Len(); j++ {
+				terms = append(terms, e.Term(j).Type())
+			}
+		default:
+			if ei, ok := e.Underlying().(*types.Interface); ok {
+				if ei.IsMethodSet() {
+					// methods do not restrict the underlying types.
+					continue
+				}
+				terms = append(terms, coreTypeOfTerms(ei))
+				break
+			}
+			terms = append(terms, e)
+		}
+		for _, term := range terms {
+			if term == nil {
+				return nil
+			}
+			u := coreType(term)
+			if u == nil || ret != nil && !types.Identical(ret, u) {
+				return nil
+			}
+			ret = u
+		}
+	}
+	return ret
+}
+
+// elemExprs returns the expressions stored as elements (or map values) into the container expr.
+// The container is traced through composite literals, append calls and index assignments within the same function.
+// If the container may hold zero values, such as one made by make([]any, n), a zero element is included in exprs.
+// ok is false if some elements cannot be determined, e.g. expr is a parameter or a result of a function call,
+// or the container may be modified through other than the variable.
+func elemExprs(pass *analysis.Pass, expr ast.Expr) (exprs []ast.Expr, ok bool) {
+	expr = astutil.Unparen(expr)
+	if tv, ok := pass.TypesInfo.Types[expr]; ok && tv.IsNil() {
+		return nil, true
+	}
+	switch e := expr.(type) {
+	case *ast.CompositeLit:
+		return compositeLitElems(pass, e), true
+	case *ast.UnaryExpr:
+		if e.Op == token.AND {
+			if lit, ok := astutil.Unparen(e.X).(*ast.CompositeLit); ok {
+				return compositeLitElems(pass, lit), true
+			}
+		}
+	case *ast.Ident:
+		v, _ := pass.TypesInfo.ObjectOf(e).(*types.Var)
+		if v == nil || !isLocal(v) {
+			return nil, false
+		}
+		return localElemExprs(pass, v, e)
+	case *ast.CallExpr:
+		if isBuiltin(pass, e, "append") {
+			return appendElemExprs(pass, e, nil)
+		}
+		if isBuiltin(pass, e, "make") {
+			return makeElems(pass, e), true
+		}
+	}
+	return nil, false
+}
+
+// zeroElem is a synthetic expression denoting the zero value of an element.
+type zeroElem struct {
+	*ast.Ident
+}
+
+// newZeroElem returns the zero element of the container created at pos.
+func newZeroElem(pos token.Pos) ast.Expr {
+	return zeroElem{Ident: &ast.Ident{NamePos: pos, Name: "nil"}}
+}
+
+func isZeroElem(expr ast.Expr) bool {
+	_, ok := expr.(zeroElem)
+	return ok
+}
+
+// makeElems returns the elements of the slice created by the make call.
+// Maps created by make have no elements, and slices of non-zero length have zero elements.
+func makeElems(pass *analysis.Pass, call *ast.CallExpr) []ast.Expr {
+	if _, ok := pass.TypesInfo.TypeOf(call).Underlying().(*types.Slice); !ok || len(call.Args) < 2 {
+		return nil
+	}
+	if n := pass.TypesInfo.Types[call.Args[1]].Value; n != nil && constant.Sign(n) == 0 {
+		return nil
+	}
+	return []ast.Expr{newZeroElem(call.Pos())}
+}
+
+// compositeLitElems returns the elements of the composite literal.
+// If lit is an array literal with fewer elements than its length, the zero element is included.
+func compositeLitElems(pass *analysis.Pass, lit *ast.CompositeLit) []ast.Expr {
+	ret := make([]ast.Expr, 0, len(lit.Elts))
+	for _, elt := range lit.Elts {
+		if kv, ok := elt.(*ast.KeyValueExpr); ok {
+			ret = append(ret, kv.Value)
+			continue
+		}
+		ret = append(ret, elt)
+	}
+	if a, ok := arrayOf(pass.TypesInfo.TypeOf(lit)); ok && a.Len() > int64(len(lit.Elts)) {
+		ret = append(ret, newZeroElem(lit.Pos()))
+	}
+	return ret
+}
+
+// arrayOf returns the array type of typ if typ is an array.
+func arrayOf(typ types.Type) (*types.Array, bool) {
+	if typ == nil {
+		return nil, false
+	}
+	a, ok := typ.Underlying().(*types.Array)
+	return a, ok
+}
+
+// appendElemExprs returns the elements of the result of the append call.
+// If the first argument of the call is self, the elements of self are not included.
+func appendElemExprs(pass *analysis.Pass, call *ast.CallExpr, self *types.Var) ([]ast.Expr, bool) {
+	if len(call.Args) == 0 {
+		return nil, true
+	}
+	var ret []ast.Expr
+	if base, ok := astutil.Unparen(call.Args[0]).(*ast.Ident); !ok || self == nil || pass.TypesInfo.ObjectOf(base) != self {
+		exprs, ok := elemExprs(pass, call.Args[0])
+		if !ok {
+			return nil, false
+		}
+		ret = append(ret, exprs...)
+	}
+	rest := call.Args[1:]
+	if call.Ellipsis.IsValid() {
+		if len(rest) != 1 {
+			return nil, false
+		}
+		exprs, ok := elemExprs(pass, rest[0])
+		if !ok {
+			return nil, false
+		}
+		return append(ret, exprs...), true
+	}
+	return append(ret, rest...), true
+}
+
+// localElemExprs returns the elements stored into the local variable v, which is used at use.
+// ok is false if the container may be modified other than by the assignments to v and its elements.
+func localElemExprs(pass *analysis.Pass, v *types.Var, use *ast.Ident) ([]ast.Expr, bool) {
+	file := fileOf(pass, v.Pos())
+	if file == nil {
+		return nil, false
+	}
+	if escaped(pass, file, v, use) {
+		return nil, false
+	}
+	var ret []ast.Expr
+	// declared is true if v is declared by an assignment or a var declaration,
+	// i.e., v is not a parameter nor a range variable.
+	declared := false
+	ok := true
+	ast.Inspect(file, func(n ast.Node) bool {
+		if !ok {
+			return false
+		}
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for i, lhs := range n.Lhs {
+				lhs = astutil.Unparen(lhs)
+				if idx, isIndex := lhs.(*ast.IndexExpr); isIndex && isVar(pass, idx.X, v) {
+					if len(n.Lhs) != len(n.Rhs) {
+						ok = false
+						return false
+					}
+					ret = append(ret, n.Rhs[i])
+					continue
+				}
+				if !isVar(pass, lhs, v) {
+					continue
+				}
+				if lhs.Pos() == v.Pos() {
+					declared = true
+				}
+				if len(n.Lhs) != len(n.Rhs) {
+					ok = false
+					return false
+				}
+				var exprs []ast.Expr
+				if call, isCall := astutil.Unparen(n.Rhs[i]).(*ast.CallExpr); isCall && isBuiltin(pass, call, "append") {
+					exprs, ok = appendElemExprs(pass, call, v)
+				} else {
+					exprs, ok = elemExprs(pass, n.Rhs[i])
+				}
+				if !ok {
+					return false
+				}
+				ret = append(ret, exprs...)
+			}
+		case *ast.ValueSpec:
+			for i, name := range n.Names {
+				if pass.TypesInfo.ObjectOf(name) != v {
+					continue
+				}
+				declared = true
+				if len(n.Values) == 0 {
+					// the elements of an array are zero values.
+					if a, isArray := arrayOf(v.Type()); isArray && a.Len() > 0 {
+						ret = append(ret, newZeroElem(name.Pos()))
+					}
+					continue
+				}
+				if len(n.Names) != len(n.Values) {
+					ok = false
+					return false
+				}
+				var exprs []ast.Expr
+				exprs, ok = elemExprs(pass, n.Values[i])
+				if !ok {
+					return false
+				}
+				ret = append(ret, exprs...)
+			}
+		}
+		return true
+	})
+	if !ok || !declared {
+		return nil, false
+	}
+	return ret, true
+}
+
+// escaped reports whether the container in the local variable v may be modified through other than v,
+// that is, v is used other than at use in a way that the container is passed to a function, copied into,
+// appended to another variable, aliased, or its elements are addressed.
+func escaped(pass *analysis.Pass, file *ast.File, v *types.Var, use *ast.Ident) bool {
+	var stack []ast.Node
+	ret := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		if ret {
+			return false
+		}
+		if n == nil {
+			stack = stack[:len(stack)-1]
+			return true
+		}
+		if id, ok := n.(*ast.Ident); ok && id != use && pass.TypesInfo.Uses[id] == v && escapesAt(pass, v, id, stack) {
+			ret = true
+			return false
+		}
+		stack = append(stack, n)
+		return true
+	})
+	return ret
+}
+
+// escapesAt reports whether the use id of the container v escapes, where stack is the path from the file to id.
+func escapesAt(pass *analysis.Pass, v *types.Var, id *ast.Ident, stack []ast.Node) bool {
+	child, i := parentOf(id, stack)
+	if i < 0 {
+		return true
+	}
+	switch p := stack[i].(type) {
+	case *ast.AssignStmt:
+		// assignments to v are traced, but the others alias the container.
+		for _, lhs := range p.Lhs {
+			if lhs == child {
+				return false
+			}
+		}
+		return true
+	case *ast.IndexExpr:
+		if p.X != child {
+			return true
+		}
+		// reading and assigning elements are safe unless their addresses are taken.
+		_, j := parentOf(p, stack[:i])
+		u, ok := at(stack, j).(*ast.UnaryExpr)
+		return ok && u.Op == token.AND
+	case *ast.RangeStmt:
+		return p.X != child
+	case *ast.BinaryExpr:
+		// comparison with nil
+		return false
+	case *ast.CallExpr:
+		switch {
+		case isBuiltin(pass, p, "len"), isBuiltin(pass, p, "cap"), isBuiltin(pass, p, "delete"), isBuiltin(pass, p, "clear"):
+			return false
+		case isBuiltin(pass, p, "copy"):
+			// copying from v is safe, but copying into v is not traced.
+			return len(p.Args) == 0 || p.Args[0] == child
+		case isBuiltin(pass, p, "append"):
+			if len(p.Args) > 0 && p.Args[0] != child {
+				// the elements of v are appended to another container.
+				return false
+			}
+			// the result may share the backing array with v, so it must be assigned back to v.
+			_, j := parentOf(p, stack[:i])
+			assign, ok := at(stack, j).(*ast.AssignStmt)
+			if !ok || len(assign.Lhs) != len(assign.Rhs) {
+				return true
+			}
+			for k, rhs := range assign.Rhs {
+				if astutil.Unparen(rhs) == p {
+					return !isVar(pass, assign.Lhs[k], v)
+				}
+			}
+			return true
+		}
+		// the function may modify the container.
+		return true
+	}
+	return true
+}
+
+// parentOf returns the outermost parenthesized expression of n and the index of its parent in stack,
+// where stack is the path to n excluding n. If n has no parent, the index is -1.
+func parentOf(n ast.Node, stack []ast.Node) (ast.Node, int) {
+	i := len(stack) - 1
+	for ; i >= 0; i-- {
+		p, ok := stack[i].(*ast.ParenExpr)
+		if !ok {
+			break
+		}
+		n = p
+	}
+	return n, i
+}
+
+// at returns stack[i], or nil if i is out of range.
+func at(stack []ast.Node, i int) ast.Node {
+	if i < 0 || i >= len(stack) {
+		return nil
+	}
+	return stack[i]
+}
+
+// isLocal reports whether v is a local variable (not a package-level variable nor a field).
+func isLocal(v *types.Var) bool {
+	if v.IsField() || v.Pkg() == nil {
+		return false
+	}
+	return v.Parent() != nil && v.Parent() != v.Pkg().Scope()
+}
+
+func isVar(pass *analysis.Pass, expr ast.Expr, v *types.Var) bool {
+	id, ok := astutil.Unparen(expr).(*ast.Ident)
+	return ok && pass.TypesInfo.ObjectOf(id) == v
+}
+
+func isBuiltin(pass *analysis.Pass, call *ast.CallExpr, name string) bool {
+	id, ok := astutil.Unparen(call.Fun).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	b, ok := pass.TypesInfo.ObjectOf(id).(*types.Builtin)
+	return ok && b.Name() == name
+}
+
+func fileOf(pass *analysis.Pass, pos token.Pos) *ast.File {
+	for _, f := range pass.Files {
+		if f.Pos() <= pos && pos <= f.End() {
+			return f
+		}
+	}
+	return nil
+}
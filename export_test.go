@@ -6,6 +6,28 @@ type ErrInvalidFuncName = errInvalidFuncName
 
 type ErrIdentNotFound = errIdentNotFound
 
+type ErrAmbiguousPkgName = errAmbiguousPkgName
+
 type ErrNotFunc = errNotFunc
 
 type ErrNotMethod = errNotMethod
+
+type ErrNotVar = errNotVar
+
+type ErrNotContainer = errNotContainer
+
+type ErrParamKindMismatch = errParamKindMismatch
+
+type ErrNotInterface = errNotInterface
+
+type ErrInvalidConst = errInvalidConst
+
+type ErrInvalidTypeExpr = errInvalidTypeExpr
+
+type ErrNotInterfaceType = errNotInterfaceType
+
+type ErrWildcardWithoutPkgPath = errWildcardWithoutPkgPath
+
+type ErrWrappersNotSupported = errWrappersNotSupported
+
+type ErrConflictingFields = errConflictingFields
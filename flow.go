@@ -0,0 +1,285 @@
+package notany
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+	"golang.org/x/tools/go/ssa"
+)
+
+// flow computes the dynamic types flowing into interface-typed arguments within a function.
+type flow struct {
+	pass *analysis.Pass
+	// funcs is the functions of the package in the SSA form, built lazily.
+	funcs []*ssa.Function
+	built bool
+	calls map[token.Pos]ssa.CallInstruction
+	// exprs maps values to the expressions defining them for each function.
+	exprs map[*ssa.Function]map[ssa.Value]ast.Expr
+	// forwarded is the set of the arguments forwarded by wrapper functions.
+	forwarded map[forwarding]struct{}
+}
+
+func newFlow(pass *analysis.Pass) *flow {
+	return &flow{
+		pass: pass,
+	}
+}
+
+// flowNotAllowed returns the first dynamic type of the argument arg of the call n which is not allowed by t.
+// Each dynamic type is checked with the policy of t as if the expression introducing it were passed directly.
+// If the dynamic types cannot be determined, the static type of arg is checked instead.
+// If nil is returned, it means that all the dynamic types are allowed.
+func flowNotAllowed(pass *analysis.Pass, f *flow, t *analysisTarget, n *ast.CallExpr, arg callArg, sig *types.Signature) *notAllowed {
+	fts, ok := f.DynamicTypes(n, t.ArgPos, sig)
+	if !ok {
+		return checkArg(pass, t, n, arg, t.ArgPos)
+	}
+	for _, ft := range fts {
+		if ft.Expr == nil {
+			if ft.Nil {
+				if t.AllowNil {
+					continue
+				}
+				return &notAllowed{
+					ArgExpr: arg.Expr,
+					ArgType: ft.Type,
+					ArgPos:  t.ArgPos,
+					Nil:     true,
+				}
+			}
+			// the argument is checked with the dynamic type instead.
+			if result := checkArg(pass, t, n, callArg{Expr: arg.Expr, Type: ft.Type}, t.ArgPos); result != nil {
+				return result
+			}
+			continue
+		}
+		if ft.Nil && !isNil(pass, ft.Expr) {
+			// the zero value of a variable declared without a value.
+			if t.AllowNil {
+				continue
+			}
+			return &notAllowed{
+				ArgExpr: ft.Expr,
+				ArgType: ft.Type,
+				ArgPos:  t.ArgPos,
+				Nil:     true,
+				Origin:  ft.Expr.Pos(),
+			}
+		}
+		origin := callArg{Expr: ft.Expr, Type: ft.Type}
+		if result := checkArg(pass, t, n, origin, t.ArgPos); result != nil {
+			result.Origin = ft.Expr.Pos()
+			return result
+		}
+	}
+	return nil
+}
+
+// flowType is a dynamic type flowing into an interface-typed value.
+type flowType struct {
+	Type types.Type
+	// Expr is the expression that introduces Type.
+	// It is nil if unknown.
+	Expr ast.Expr
+	// Nil is true if the value is nil.
+	Nil bool
+}
+
+// DynamicTypes returns the dynamic types of the argument at argPos of the call n.
+// ok is false if some of the dynamic types cannot be determined.
+func (f *flow) DynamicTypes(n *ast.CallExpr, argPos int, sig *types.Signature) (ret []flowType, ok bool) {
+	call := f.callOf(n)
+	if call == nil {
+		return nil, false
+	}
+	args := call.Common().Args
+	// static method calls have the receiver as the first argument.
+	offset := len(args) - sig.Params().Len()
+	if offset < 0 || offset+argPos >= len(args) {
+		return nil, false
+	}
+	origins, ok := dynamicTypes(args[offset+argPos], make(map[ssa.Value]struct{}))
+	if !ok {
+		return nil, false
+	}
+	exprs := f.exprsOf(call.Parent())
+	for _, v := range origins {
+		ft := flowType{Type: v.Type()}
+		if mi, ok := v.(*ssa.MakeInterface); ok {
+			ft.Type = mi.X.Type()
+		}
+		if expr, ok := exprs[v]; ok {
+			ft.Expr = definingExpr(f.pass, expr)
+		}
+		if c, ok := v.(*ssa.Const); ok && c.IsNil() {
+			ft.Type = types.Typ[types.UntypedNil]
+			ft.Nil = true
+		}
+		ret = append(ret, ft)
+	}
+	return ret, true
+}
+
+func (f *flow) callOf(n *ast.CallExpr) ssa.CallInstruction {
+	if f.calls == nil {
+		f.calls = make(map[token.Pos]ssa.CallInstruction)
+		for _, fn := range f.Funcs() {
+			for _, b := range fn.Blocks {
+				for _, instr := range b.Instrs {
+					if c, ok := instr.(ssa.CallInstruction); ok {
+						f.calls[c.Common().Pos()] = c
+					}
+				}
+			}
+		}
+	}
+	return f.calls[n.Lparen]
+}
+
+// exprsOf returns the expressions defining the values in fn.
+// The first reference to a value is its defining expression.
+func (f *flow) exprsOf(fn *ssa.Function) map[ssa.Value]ast.Expr {
+	if f.exprs == nil {
+		f.exprs = make(map[*ssa.Function]map[ssa.Value]ast.Expr)
+	}
+	if exprs, ok := f.exprs[fn]; ok {
+		return exprs
+	}
+	exprs := make(map[ssa.Value]ast.Expr)
+	for _, b := range fn.Blocks {
+		for _, instr := range b.Instrs {
+			ref, ok := instr.(*ssa.DebugRef)
+			if !ok || ref.IsAddr {
+				continue
+			}
+			if _, ok := exprs[ref.X]; !ok {
+				exprs[ref.X] = ref.Expr
+			}
+		}
+	}
+	f.exprs[fn] = exprs
+	return exprs
+}
+
+// definingExpr returns the expression whose value is referred to by expr.
+// For a variable assigned in expr, it is the assigned expression.
+// For a conversion, it is the converted expression.
+func definingExpr(pass *analysis.Pass, expr ast.Expr) ast.Expr {
+	expr = astutil.Unparen(expr)
+	switch e := expr.(type) {
+	case *ast.Ident:
+		file := fileOf(pass, e.Pos())
+		if file == nil {
+			return expr
+		}
+		path, _ := astutil.PathEnclosingInterval(file, e.Pos(), e.End())
+		if len(path) < 2 {
+			return expr
+		}
+		var lhs, rhs []ast.Expr
+		switch p := path[1].(type) {
+		case *ast.AssignStmt:
+			lhs, rhs = p.Lhs, p.Rhs
+		case *ast.ValueSpec:
+			for _, name := range p.Names {
+				lhs = append(lhs, name)
+			}
+			rhs = p.Values
+		}
+		if len(lhs) != len(rhs) {
+			return expr
+		}
+		for i := range lhs {
+			if lhs[i] == e {
+				return definingExpr(pass, rhs[i])
+			}
+		}
+	case *ast.CallExpr:
+		if tv, ok := pass.TypesInfo.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 && types.IsInterface(tv.Type) {
+			return definingExpr(pass, e.Args[0])
+		}
+	}
+	return expr
+}
+
+// Funcs returns the functions declared in the package including function literals.
+// The SSA form is built only when it is first needed.
+// Debug information is recorded so that values are mapped back to the expressions.
+func (f *flow) Funcs() []*ssa.Function {
+	if f.built {
+		return f.funcs
+	}
+	f.built = true
+	prog := ssa.NewProgram(f.pass.Fset, ssa.GlobalDebug)
+	created := make(map[*types.Package]struct{})
+	var create func(pkgs []*types.Package)
+	create = func(pkgs []*types.Package) {
+		for _, p := range pkgs {
+			if _, ok := created[p]; ok {
+				continue
+			}
+			created[p] = struct{}{}
+			prog.CreatePackage(p, nil, nil, true)
+			create(p.Imports())
+		}
+	}
+	create(f.pass.Pkg.Imports())
+	prog.CreatePackage(f.pass.Pkg, f.pass.Files, f.pass.TypesInfo, false).Build()
+
+	var addAnons func(fn *ssa.Function)
+	addAnons = func(fn *ssa.Function) {
+		f.funcs = append(f.funcs, fn)
+		for _, anon := range fn.AnonFuncs {
+			addAnons(anon)
+		}
+	}
+	for _, file := range f.pass.Files {
+		for _, decl := range file.Decls {
+			decl, ok := decl.(*ast.FuncDecl)
+			if !ok || decl.Name.Name == "_" {
+				continue
+			}
+			obj, ok := f.pass.TypesInfo.Defs[decl.Name].(*types.Func)
+			if !ok {
+				continue
+			}
+			if fn := prog.FuncValue(obj); fn != nil {
+				addAnons(fn)
+			}
+		}
+	}
+	return f.funcs
+}
+
+// dynamicTypes returns the conversions into the interface-typed value v and the nil constants flowing into v.
+// ok is false if some of them cannot be determined.
+func dynamicTypes(v ssa.Value, seen map[ssa.Value]struct{}) (ret []ssa.Value, ok bool) {
+	if _, ok := seen[v]; ok {
+		return nil, true
+	}
+	seen[v] = struct{}{}
+	switch v := v.(type) {
+	case *ssa.MakeInterface:
+		return []ssa.Value{v}, true
+	case *ssa.ChangeInterface:
+		return dynamicTypes(v.X, seen)
+	case *ssa.Phi:
+		for _, e := range v.Edges {
+			vs, ok := dynamicTypes(e, seen)
+			if !ok {
+				return nil, false
+			}
+			ret = append(ret, vs...)
+		}
+		return ret, true
+	case *ssa.Const:
+		if v.IsNil() {
+			return []ssa.Value{v}, true
+		}
+	}
+	return nil, false
+}
@@ -1,11 +1,11 @@
 module github.com/qawatake/notany
 
-go 1.21.3
+go 1.22.0
 
 require (
 	github.com/gostaticanalysis/analysisutil v0.7.1
 	github.com/gostaticanalysis/testutil v0.4.0
-	golang.org/x/tools v0.14.0
+	golang.org/x/tools v0.26.0
 )
 
 require (
@@ -14,7 +14,7 @@ require (
 	github.com/otiai10/copy v1.2.0 // indirect
 	github.com/tenntenn/modver v1.0.1 // indirect
 	github.com/tenntenn/text/transform v0.0.0-20200319021203-7eef512accb3 // indirect
-	golang.org/x/mod v0.13.0 // indirect
-	golang.org/x/sys v0.13.0 // indirect
+	golang.org/x/mod v0.21.0 // indirect
+	golang.org/x/sync v0.8.0 // indirect
 	golang.org/x/text v0.3.8 // indirect
 )
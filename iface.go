@@ -0,0 +1,169 @@
+package notany
+
+import (
+	"go/ast"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// allowInterface reports whether the interface-typed argument arg passed through n is accepted by the interface policy of t.
+// n is the call or the statement through which arg is passed.
+func allowInterface(pass *analysis.Pass, t *analysisTarget, n ast.Node, arg callArg) bool {
+	if arg.Type == nil || !types.IsInterface(arg.Type) {
+		return false
+	}
+	switch t.Interface {
+	case InterfaceAccept:
+		return true
+	case InterfaceNarrowed:
+		return narrowed(pass, t, n, arg.Expr)
+	case InterfaceAssume:
+		return assumed(pass, t, n)
+	}
+	return false
+}
+
+// narrowed reports whether n is dominated by a type switch or a type assertion
+// which narrows arg to types allowed by t.
+func narrowed(pass *analysis.Pass, t *analysisTarget, n ast.Node, arg ast.Expr) bool {
+	id, ok := astutil.Unparen(arg).(*ast.Ident)
+	if !ok {
+		return false
+	}
+	v, ok := pass.TypesInfo.ObjectOf(id).(*types.Var)
+	if !ok {
+		return false
+	}
+	file := fileOf(pass, n.Pos())
+	if file == nil {
+		return false
+	}
+	path, _ := astutil.PathEnclosingInterval(file, n.Pos(), n.End())
+	for i, node := range path {
+		switch node := node.(type) {
+		case *ast.CaseClause:
+			// path[i+1] is the body of the switch statement.
+			if i+2 >= len(path) {
+				continue
+			}
+			ts, ok := path[i+2].(*ast.TypeSwitchStmt)
+			if !ok || node.List == nil {
+				continue
+			}
+			if pass.TypesInfo.Implicits[node] != v && !isVar(pass, typeSwitchX(ts), v) {
+				continue
+			}
+			if allowAll(pass, t, node.List) {
+				return true
+			}
+		case *ast.IfStmt:
+			if i == 0 || path[i-1] != node.Body {
+				continue
+			}
+			if x, typ, ok := commaOkAssertion(pass, node); ok && isVar(pass, x, v) && allowAll(pass, t, []ast.Expr{typ}) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// typeSwitchX returns x of the type switch statement in the form switch x.(type) or switch y := x.(type).
+func typeSwitchX(ts *ast.TypeSwitchStmt) ast.Expr {
+	var ta *ast.TypeAssertExpr
+	switch s := ts.Assign.(type) {
+	case *ast.ExprStmt:
+		ta, _ = s.X.(*ast.TypeAssertExpr)
+	case *ast.AssignStmt:
+		if len(s.Rhs) == 1 {
+			ta, _ = s.Rhs[0].(*ast.TypeAssertExpr)
+		}
+	}
+	if ta == nil {
+		return nil
+	}
+	return ta.X
+}
+
+// commaOkAssertion returns x and T of the if statement in the form if _, ok := x.(T); ok { ... }.
+func commaOkAssertion(pass *analysis.Pass, stmt *ast.IfStmt) (x, typ ast.Expr, ok bool) {
+	assign, isAssign := stmt.Init.(*ast.AssignStmt)
+	if !isAssign || len(assign.Lhs) != 2 || len(assign.Rhs) != 1 {
+		return nil, nil, false
+	}
+	ta, isAssert := astutil.Unparen(assign.Rhs[0]).(*ast.TypeAssertExpr)
+	if !isAssert {
+		return nil, nil, false
+	}
+	okVar, isIdent := assign.Lhs[1].(*ast.Ident)
+	if !isIdent {
+		return nil, nil, false
+	}
+	cond, isIdent := astutil.Unparen(stmt.Cond).(*ast.Ident)
+	if !isIdent || pass.TypesInfo.ObjectOf(cond) != pass.TypesInfo.ObjectOf(okVar) {
+		return nil, nil, false
+	}
+	return ta.X, ta.Type, true
+}
+
+// allowAll reports whether all the types denoted by the type expressions are allowed by t.
+// nil in a case clause is allowed if t allows nil.
+func allowAll(pass *analysis.Pass, t *analysisTarget, typeExprs []ast.Expr) bool {
+	for _, e := range typeExprs {
+		if isNil(pass, e) {
+			if !t.AllowNil {
+				return false
+			}
+			continue
+		}
+		typ := pass.TypesInfo.TypeOf(e)
+		if typ == nil || !t.Allow(typ) {
+			return false
+		}
+	}
+	return true
+}
+
+// assumed reports whether n is annotated with //notany:assume T and T is allowed by t.
+func assumed(pass *analysis.Pass, t *analysisTarget, n ast.Node) bool {
+	args, ok := directive(pass, n.Pos(), "assume")
+	if !ok || len(args) == 0 {
+		return false
+	}
+	for _, arg := range args {
+		typs, err := toAllowedTypes(pass, []Allowed{parseQualifiedType(arg)})
+		if err != nil {
+			return false
+		}
+		if len(typs.Matchers) > 0 {
+			// the matched types cannot be enumerated.
+			return false
+		}
+		for typ := range typs.Types {
+			if !t.Allow(typ) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// parseQualifiedType parses the type name qualified with its package path such as time.Time or *time.Time.
+func parseQualifiedType(s string) Allowed {
+	ptr := ""
+	if strings.HasPrefix(s, "*") {
+		ptr = "*"
+		s = s[1:]
+	}
+	i := strings.LastIndex(s, ".")
+	if i < 0 {
+		return Allowed{TypeName: ptr + s}
+	}
+	return Allowed{
+		PkgPath:  s[:i],
+		TypeName: ptr + s[i+1:],
+	}
+}
@@ -48,6 +48,39 @@ func ObjectOfBFS(pkg *types.Package, path, name string) types.Object {
 	return lookupper.Lookup(path, name)
 }
 
+// PackageOfBFS returns the package of the path among pkg and its imports.
+func PackageOfBFS(pkg *types.Package, path string) *types.Package {
+	var ret *types.Package
+	WalkPackagesBFS(pkg, func(p *types.Package) bool {
+		if analysisutil.RemoveVendor(p.Path()) == analysisutil.RemoveVendor(path) {
+			ret = p
+			return false
+		}
+		return true
+	})
+	return ret
+}
+
+// WalkPackagesBFS calls f for pkg and its imports in breadth-first order until f returns false.
+func WalkPackagesBFS(pkg *types.Package, f func(p *types.Package) bool) {
+	seen := map[*types.Package]struct{}{pkg: {}}
+	queue := []*types.Package{pkg}
+	for len(queue) > 0 {
+		p := queue[0]
+		queue = queue[1:]
+		if !f(p) {
+			return
+		}
+		for _, imp := range p.Imports() {
+			if _, ok := seen[imp]; ok {
+				continue
+			}
+			seen[imp] = struct{}{}
+			queue = append(queue, imp)
+		}
+	}
+}
+
 type lookupperBFS struct {
 	seen  map[*types.Package]struct{}
 	queue *list.List
@@ -0,0 +1,232 @@
+package notany
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// keyFact is exported for a constant annotated with //notany:key.
+type keyFact struct {
+	Allowed []Allowed
+}
+
+func (*keyFact) AFact() {}
+
+func (f *keyFact) String() string {
+	names := make([]string, 0, len(f.Allowed))
+	for _, a := range f.Allowed {
+		if a.PkgPath == "" {
+			names = append(names, a.TypeName)
+			continue
+		}
+		names = append(names, a.PkgPath+"."+a.TypeName)
+	}
+	return fmt.Sprintf("key(%s)", strings.Join(names, " "))
+}
+
+// exportKeyFacts exports facts for the constants annotated with //notany:key in the package.
+func exportKeyFacts(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				vs := spec.(*ast.ValueSpec)
+				groups := []*ast.CommentGroup{vs.Doc, vs.Comment}
+				if !gd.Lparen.IsValid() {
+					groups = append(groups, gd.Doc)
+				}
+				args, pos, ok := declDirective(groups, "key")
+				if !ok {
+					continue
+				}
+				fact := new(keyFact)
+				for _, arg := range args {
+					a := parseQualifiedType(arg)
+					if _, err := toAllowedTypes(pass, []Allowed{a}); err != nil {
+						pass.Reportf(pos, "invalid type %s in //notany:key: %v", arg, err)
+						continue
+					}
+					fact.Allowed = append(fact.Allowed, a)
+				}
+				for _, name := range vs.Names {
+					if c, ok := pass.TypesInfo.Defs[name].(*types.Const); ok {
+						pass.ExportObjectFact(c, fact)
+					}
+				}
+			}
+		}
+	}
+}
+
+// keyAllowed is the policy for the argument selected by a key.
+type keyAllowed struct {
+	Allowed *allowedTypes
+	Values  []*valueConstraint
+	// AllowedList is the list from which Allowed is built.
+	AllowedList []Allowed
+}
+
+// toKeyedAllowedTypes returns the allowed types for the keys written as Go constant expressions.
+// If skipUnresolvable is true, the allowed types not found from the package are ignored instead of being errors.
+func toKeyedAllowedTypes(pass *analysis.Pass, keys map[string][]Allowed, skipUnresolvable bool) (map[string]*keyAllowed, error) {
+	ret := make(map[string]*keyAllowed, len(keys))
+	for k, list := range keys {
+		val, err := evalConst(k)
+		if err != nil {
+			return nil, err
+		}
+		resolved := list
+		if skipUnresolvable {
+			resolved = resolvable(pass, list)
+		}
+		allowed, err := toAllowedTypes(pass, resolved)
+		if err != nil {
+			return nil, err
+		}
+		values, err := toValueConstraints(pass, resolved)
+		if err != nil {
+			return nil, err
+		}
+		ret[val.ExactString()] = &keyAllowed{
+			Allowed:     allowed,
+			Values:      values,
+			AllowedList: list,
+		}
+	}
+	return ret, nil
+}
+
+// keyNotAllowed returns the result if the argument at ArgPos is not allowed for the key argument at KeyArgPos.
+func keyNotAllowed(pass *analysis.Pass, t *analysisTarget, n *ast.CallExpr, args []callArg) *notAllowed {
+	if t.ArgPos >= len(args) || t.KeyArgPos >= len(args) {
+		return nil
+	}
+	key := pass.TypesInfo.Types[args[t.KeyArgPos].Expr].Value
+	if key == nil {
+		if t.NonConstKey == NonConstAccept {
+			return nil
+		}
+		return &notAllowed{
+			ArgPos:      t.ArgPos,
+			KeyArgPos:   t.KeyArgPos,
+			NonConstKey: true,
+		}
+	}
+	k, ok := allowedForKey(pass, t, args[t.KeyArgPos].Expr, key.ExactString())
+	if !ok {
+		return &notAllowed{
+			ArgPos:     t.ArgPos,
+			Key:        key,
+			KeyArgPos:  t.KeyArgPos,
+			UnknownKey: true,
+		}
+	}
+	keyed := *t
+	keyed.Keyed = false
+	keyed.Allowed = k.Allowed
+	keyed.Values = k.Values
+	keyed.AllowedList = k.AllowedList
+	keyed.AllowNil = t.AllowNil || allowsNil(k.AllowedList)
+	arg := args[t.ArgPos]
+	if allowArg(pass, &keyed, n, arg) {
+		return nil
+	}
+	ret := argNotAllowed(pass, &keyed, arg, t.ArgPos, nil)
+	ret.Key = key
+	ret.KeyArgPos = t.KeyArgPos
+	return ret
+}
+
+// allowedForKey returns the policy for the key argument keyExpr whose exact string is key.
+// The keys configured take precedence over the keys annotated with //notany:key.
+// An annotated constant is a key if the key argument refers to it, or if it is declared in the package of the target function.
+func allowedForKey(pass *analysis.Pass, t *analysisTarget, keyExpr ast.Expr, key string) (*keyAllowed, bool) {
+	if allowed, ok := t.Keys[key]; ok {
+		return allowed, true
+	}
+	if t.KeyIndex == nil {
+		return nil, false
+	}
+	if c := constOf(pass, keyExpr); c != nil {
+		if allowed, ok := t.KeyIndex.byConst[c]; ok {
+			return allowed, true
+		}
+	}
+	allowed, ok := t.KeyIndex.byPkg[t.Func.Pkg()][key]
+	return allowed, ok
+}
+
+// constOf returns the constant referred to by expr, or nil if expr does not refer to a named constant.
+func constOf(pass *analysis.Pass, expr ast.Expr) *types.Const {
+	switch e := astutil.Unparen(expr).(type) {
+	case *ast.Ident:
+		c, _ := pass.TypesInfo.ObjectOf(e).(*types.Const)
+		return c
+	case *ast.SelectorExpr:
+		c, _ := pass.TypesInfo.ObjectOf(e.Sel).(*types.Const)
+		return c
+	}
+	return nil
+}
+
+// keyIndex indexes the constants annotated with //notany:key in the package and its dependencies.
+type keyIndex struct {
+	// byConst maps the annotated constants to their allowed types.
+	byConst map[*types.Const]*keyAllowed
+	// byPkg maps the exact strings of the annotated constants to their allowed types for each package declaring them.
+	byPkg map[*types.Package]map[string]*keyAllowed
+}
+
+// newKeyIndex indexes the key facts.
+// It must be called after the facts of the package are exported.
+func newKeyIndex(pass *analysis.Pass) *keyIndex {
+	idx := &keyIndex{
+		byConst: make(map[*types.Const]*keyAllowed),
+		byPkg:   make(map[*types.Package]map[string]*keyAllowed),
+	}
+	for _, f := range pass.AllObjectFacts() {
+		kf, ok := f.Fact.(*keyFact)
+		if !ok {
+			continue
+		}
+		c, ok := f.Object.(*types.Const)
+		if !ok {
+			continue
+		}
+		allowed := newAllowedTypes()
+		for _, a := range kf.Allowed {
+			// types not reachable from the package cannot be passed, so they are ignored.
+			typs, err := toAllowedTypes(pass, []Allowed{a})
+			if err != nil {
+				continue
+			}
+			allowed.Add(typs)
+		}
+		idx.byConst[c] = &keyAllowed{
+			Allowed:     allowed,
+			AllowedList: kf.Allowed,
+		}
+		keys := idx.byPkg[c.Pkg()]
+		if keys == nil {
+			keys = make(map[string]*keyAllowed)
+			idx.byPkg[c.Pkg()] = keys
+		}
+		// constants with the same value are merged, so that the result does not depend on the order of the facts.
+		key := c.Val().ExactString()
+		if keys[key] == nil {
+			keys[key] = &keyAllowed{Allowed: newAllowedTypes()}
+		}
+		keys[key].Allowed.Add(allowed)
+		keys[key].AllowedList = append(keys[key].AllowedList, kf.Allowed...)
+	}
+	return idx
+}
@@ -0,0 +1,113 @@
+package notany
+
+import (
+	"go/types"
+)
+
+// allowedTypes is a set of allowed types.
+type allowedTypes struct {
+	// Types is the set of the types allowed as they are.
+	Types map[types.Type]struct{}
+	// Matchers match the types which cannot be listed in advance, such as the instances of a generic type.
+	Matchers []matcher
+}
+
+// matcher matches types by a rule instead of by identity.
+type matcher interface {
+	Match(typ types.Type) bool
+	String() string
+}
+
+func newAllowedTypes() *allowedTypes {
+	return &allowedTypes{
+		Types: make(map[types.Type]struct{}),
+	}
+}
+
+// Add adds the types and the matchers of other to s.
+func (s *allowedTypes) Add(other *allowedTypes) {
+	for typ := range other.Types {
+		s.Types[typ] = struct{}{}
+	}
+	s.Matchers = append(s.Matchers, other.Matchers...)
+}
+
+// Clone returns a copy of s which can be modified independently.
+func (s *allowedTypes) Clone() *allowedTypes {
+	ret := newAllowedTypes()
+	if s != nil {
+		ret.Add(s)
+	}
+	return ret
+}
+
+// Empty reports whether s allows no types.
+func (s *allowedTypes) Empty() bool {
+	return s == nil || len(s.Types) == 0 && len(s.Matchers) == 0
+}
+
+// Contains reports whether typ is identical to one of the types or matches one of the matchers.
+func (s *allowedTypes) Contains(typ types.Type) bool {
+	if s == nil {
+		return false
+	}
+	if _, ok := s.Types[typ]; ok {
+		return true
+	}
+	for _, m := range s.Matchers {
+		if m.Match(typ) {
+			return true
+		}
+	}
+	for at := range s.Types {
+		// instances and composite types such as []byte are not unique.
+		if types.Identical(typ, at) {
+			return true
+		}
+	}
+	return false
+}
+
+// Allow reports whether typ is contained in s or implements one of the interfaces in s.
+func (s *allowedTypes) Allow(typ types.Type) bool {
+	if s.Contains(typ) {
+		return true
+	}
+	if s == nil {
+		return false
+	}
+	for at := range s.Types {
+		if i, ok := at.Underlying().(*types.Interface); ok && types.Implements(typ, i) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchPattern matches types against typ in the mode other than MatchIdentical.
+type matchPattern struct {
+	typ  types.Type
+	mode MatchMode
+}
+
+func (p *matchPattern) String() string {
+	return p.typ.String()
+}
+
+// Match reports whether typ matches p.typ in p.mode.
+func (p *matchPattern) Match(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	switch p.mode {
+	case MatchUnderlying:
+		return types.Identical(typ.Underlying(), p.typ.Underlying())
+	case MatchAssignable:
+		return types.AssignableTo(typ, p.typ)
+	case MatchConvertible:
+		return types.ConvertibleTo(typ, p.typ)
+	case MatchExactInterface:
+		return types.Identical(typ, p.typ)
+	}
+	return types.Identical(typ, p.typ)
+}
@@ -0,0 +1,101 @@
+package notany
+
+import (
+	"fmt"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// methodSet matches the types having all the methods.
+// The methods are matched by their names and signatures, so that unexported methods of any package match as well.
+type methodSet struct {
+	names []string
+	sigs  []*types.Signature
+}
+
+// methodSetOf returns the method set consisting of the methods.
+func methodSetOf(pass *analysis.Pass, methods []Method) (*methodSet, error) {
+	ret := &methodSet{}
+	for _, m := range methods {
+		typ, _, err := evalTypeExpr(pass, "", m.Signature)
+		if err != nil {
+			return nil, err
+		}
+		sig, ok := typ.(*types.Signature)
+		if !ok || m.Name == "" {
+			return nil, newErrInvalidTypeExpr(m.Signature)
+		}
+		ret.names = append(ret.names, m.Name)
+		ret.sigs = append(ret.sigs, sig)
+	}
+	return ret, nil
+}
+
+func (m *methodSet) String() string {
+	ss := make([]string, 0, len(m.names))
+	for i, name := range m.names {
+		ss = append(ss, name+strings.TrimPrefix(m.sigs[i].String(), "func"))
+	}
+	return "interface{" + strings.Join(ss, "; ") + "}"
+}
+
+// Match reports whether the method set of typ has all the methods.
+func (m *methodSet) Match(typ types.Type) bool {
+	if typ == nil {
+		return false
+	}
+	mset := types.NewMethodSet(typ)
+	for i, name := range m.names {
+		if !hasMethod(mset, name, m.sigs[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// hasMethod reports whether mset has the method of the name and the signature regardless of the package of the name.
+func hasMethod(mset *types.MethodSet, name string, sig *types.Signature) bool {
+	for i := 0; i < mset.Len(); i++ {
+		fn := mset.At(i).Obj()
+		if fn.Name() != name {
+			continue
+		}
+		fsig, ok := fn.Type().(*types.Signature)
+		if !ok {
+			continue
+		}
+		// the receivers are ignored.
+		if types.Identical(types.NewSignatureType(nil, nil, nil, fsig.Params(), fsig.Results(), fsig.Variadic()), sig) {
+			return true
+		}
+	}
+	return false
+}
+
+// pointerImplements returns the allowed interface or method set which is not implemented by typ but by the pointer to typ.
+// If nil is returned, there is no such interface.
+func pointerImplements(t *analysisTarget, typ types.Type) fmt.Stringer {
+	if typ == nil || types.IsInterface(typ) {
+		return nil
+	}
+	if _, ok := typ.(*types.Pointer); ok {
+		return nil
+	}
+	for _, m := range t.Allowed.Matchers {
+		if ms, ok := m.(*methodSet); ok && !ms.Match(typ) && ms.Match(types.NewPointer(typ)) {
+			return ms
+		}
+	}
+	for at := range t.Allowed.Types {
+		i, ok := at.Underlying().(*types.Interface)
+		if !ok || i.Empty() {
+			continue
+		}
+		if !types.Implements(typ, i) && types.Implements(types.NewPointer(typ), i) {
+			return i
+		}
+	}
+	return nil
+}
@@ -4,12 +4,15 @@ import (
 	"errors"
 	"fmt"
 	"go/ast"
+	"go/constant"
+	"go/token"
 	"go/types"
 	"strings"
 
 	"github.com/qawatake/notany/internal/analysisutil"
 	"golang.org/x/tools/go/analysis"
 	"golang.org/x/tools/go/analysis/passes/inspect"
+	"golang.org/x/tools/go/ast/astutil"
 	"golang.org/x/tools/go/ast/inspector"
 )
 
@@ -18,10 +21,25 @@ const doc = "notany limits possible types for arguments of any type"
 const url = "https://pkg.go.dev/github.com/qawatake/notany"
 
 func NewAnalyzer(targets ...Target) *analysis.Analyzer {
+	return NewAnalyzerWithOptions(Options{}, targets...)
+}
+
+// Options configures the checks that apply to all the targets of an analyzer.
+type Options struct {
+	// If Sensitive is true, types annotated with //notany:sensitive, pointers to them, and types containing them
+	// are rejected by all the targets regardless of their allowed types. The annotated types are exported as facts.
+	// Only the static types of the arguments are checked, so a sensitive value stored in a local variable of interface type
+	// before the call is not detected unless the target traces it with Flow.
+	Sensitive bool
+}
+
+// NewAnalyzerWithOptions is the same as NewAnalyzer but configures the analyzer with opts.
+func NewAnalyzerWithOptions(opts Options, targets ...Target) *analysis.Analyzer {
 	r := &runner{
-		targets: targets,
+		targets:   targets,
+		sensitive: opts.Sensitive,
 	}
-	return &analysis.Analyzer{
+	a := &analysis.Analyzer{
 		Name: name,
 		Doc:  doc,
 		URL:  url,
@@ -30,10 +48,42 @@ func NewAnalyzer(targets ...Target) *analysis.Analyzer {
 			inspect.Analyzer,
 		},
 	}
+	for _, t := range targets {
+		r.wrappers = r.wrappers || t.Wrappers
+		r.keyDirectives = r.keyDirectives || t.KeyDirectives
+		r.allowedFor = r.allowedFor || t.AllowedFor
+	}
+	// facts are computed only if needed because they require analyzing all the dependencies.
+	if r.wrappers {
+		a.FactTypes = append(a.FactTypes, new(wrapperFact))
+	}
+	if r.keyDirectives {
+		a.FactTypes = append(a.FactTypes, new(keyFact))
+	}
+	if r.sensitive {
+		a.FactTypes = append(a.FactTypes, new(sensitiveFact))
+	}
+	if r.allowedFor {
+		a.FactTypes = append(a.FactTypes, new(allowedForFact))
+	}
+	return a
 }
 
 type runner struct {
 	targets []Target
+	// wrappers is true if any of the targets infers wrappers.
+	wrappers bool
+	// keyDirectives is true if any of the targets uses //notany:key directives.
+	keyDirectives bool
+	// sensitive is true if the global deny layer of //notany:sensitive is enabled.
+	sensitive bool
+	// allowedFor is true if any of the targets accepts the types annotated with //notany:allowed-for.
+	allowedFor bool
+}
+
+// usesFacts reports whether facts are computed, in which case the analyzer runs on all the dependencies.
+func (r *runner) usesFacts() bool {
+	return r.wrappers || r.keyDirectives || r.sensitive || r.allowedFor
 }
 
 // Target represents a pair of a function and a list of arguments with allowed types.
@@ -42,34 +92,319 @@ type Target struct {
 	PkgPath string
 	// Name of the target function (or method).
 	FuncName string
+	// Name of the target package-level variable (or struct field in the form Struct.Field).
+	// If VarName is set, FuncName and ArgPos are ignored.
+	// Values sent to the variable (if it is a channel) or assigned to it including its initial value (otherwise) are checked
+	// in the same way as arguments.
+	VarName string
 	// Position of argument of type any.
 	// ArgPos is 0-indexed.
 	ArgPos int
+	// Param specifies whether the parameter at ArgPos is checked as a single parameter or as variadic elements.
+	Param ParamKind
+	// If Elem is true, the elements (or map values) of the argument are checked instead of the argument itself.
+	// The argument must be a slice, an array, or a map.
+	Elem bool
+	// Untraced determines how elements are handled when they cannot be traced back to
+	// composite literals, append calls or index assignments.
+	// Containers which are passed to other functions, copied into, appended to other variables, aliased,
+	// or whose elements are addressed cannot be traced.
+	// It applies to the elements of the argument if Elem is true, and to spread arguments (args...) of variadic functions.
+	// If Wrappers is true, a variadic parameter spread unchanged into the argument is traced back to the callers instead.
+	Untraced UntracedPolicy
+	// If Flow is true, the dynamic types flowing into an interface-typed argument within the function are checked
+	// instead of the static type of the argument.
+	// Only a single parameter is subject to it.
+	Flow bool
+	// If Wrappers is true, functions whose parameters flow unchanged into the argument are also targets
+	// with the same policy. The inferred targets are exported as facts, so that call sites in other packages are checked.
+	// Only a single parameter is subject to it, and it cannot be combined with Keys, KeyDirectives, Relation, Pattern, or Elem.
+	// For a variadic parameter, the variadic parameter of the wrapper must be spread into it (args...) without being modified.
+	Wrappers bool
+	// Interface determines how an argument of interface type which is not allowed is handled.
+	Interface InterfacePolicy
+	// If ConstOnly is true, the argument must be a compile-time constant regardless of its type.
+	// It is checked in addition to Allowed. If no types are allowed, only the constness is checked.
+	ConstOnly bool
+	// If AllowNil is true, nil is allowed.
+	// An entry of Allowed with TypeName nil and an empty PkgPath also allows nil.
+	AllowNil bool
+	// Untyped determines how an untyped constant argument is checked.
+	Untyped UntypedPolicy
+	// Relation is the relationship required between the arguments at ArgPos and RelatedArgPos.
+	// Relation cannot be combined with Allowed, Predicates, MaxSize, Keys or Pattern.
+	Relation Relation
+	// Position of the argument related to the argument at ArgPos.
+	// RelatedArgPos is 0-indexed.
+	RelatedArgPos int
+	// Keys maps the constant values of the key argument at KeyArgPos to the allowed types for the argument at ArgPos.
+	// The keys are written as Go constant expressions such as `"timeout"`.
+	// Keys and KeyDirectives cannot be combined with Allowed, Relation or Pattern.
+	Keys map[string][]Allowed
+	// If KeyDirectives is true, the constants annotated with //notany:key T1 T2 ... are also keys with the allowed types T1, T2, ....
+	// Ti is a builtin type name or a type name qualified with its package path such as time.Duration.
+	// An annotated constant is a key for the call if it is passed as the key argument or declared in the package of the function.
+	KeyDirectives bool
+	// Position of the key argument.
+	// KeyArgPos is 0-indexed.
+	KeyArgPos int
+	// NonConstKey determines how a key argument which is not a compile-time constant is handled.
+	NonConstKey NonConstPolicy
+	// Pattern is a repeating pattern of slots over the variadic arguments from ArgPos, such as alternating keys and values.
+	// Pattern cannot be combined with Allowed, Relation or Keys, and the parameter at ArgPos must be variadic.
+	// An incomplete repetition at the end of the arguments is reported.
+	// The elements of a spread composite literal ([]any{...}...) fill the slots, and other spread arguments are handled by Untraced.
+	Pattern []Slot
+	// List of types which occupy a whole repetition of Pattern by themselves, such as slog.Attr.
+	Whole []Allowed
+	// Predicates are structural properties which the type of the argument must satisfy.
+	// If Allowed is also not empty, the type must be allowed as well.
+	Predicates []Predicate
+	// MaxSize is the maximum size in bytes of the type of the argument, which is copied when boxed into an interface.
+	// The size is computed with the sizes of the analyzed platform. Arguments of interface types are not subject to it.
+	// If MaxSize is 0, the size is not limited. If Allowed is also not empty, the type must be allowed as well.
+	MaxSize int64
+	// If Consistent is true, the calls of the method on the same field or package-level variable
+	// must pass the identical type for the argument within a package, as atomic.Value.Store requires.
+	// The calls of all the consistent targets on the same receiver, such as Store and Swap, are checked against each other.
+	// Calls on local variables and arguments of interface types are not subject to it.
+	// If Allowed is empty and there are no other constraints, only the consistency is checked.
+	Consistent bool
+	// If AllowedFor is true, the types annotated with //notany:allowed-for PkgPath.FuncName[:ArgPos] are also allowed.
+	// FuncName is in the same form as that of Target, and if :ArgPos is omitted, the types are allowed for all the targets of the function.
+	// The annotated types are exported as facts, so that they need not be reachable from the calling packages.
+	AllowedFor bool
 	// List of allowed types for the argument.
 	Allowed []Allowed
 }
 
+// Relation is a relationship required between two arguments of a call.
+// The types of untyped constants are their default types. nil is not subject to the relationship.
+type Relation int
+
+const (
+	// RelationNone requires no relationship.
+	RelationNone Relation = iota
+	// RelationIdentical requires the types of the arguments to be identical.
+	RelationIdentical
+	// RelationAssignable requires the type of the argument at ArgPos to be assignable to that at RelatedArgPos.
+	RelationAssignable
+)
+
+func (r Relation) String() string {
+	switch r {
+	case RelationNone:
+		return "none"
+	case RelationIdentical:
+		return "identical"
+	case RelationAssignable:
+		return "assignable"
+	}
+	return fmt.Sprintf("Relation(%d)", int(r))
+}
+
+// ParamKind specifies how the parameter at Target.ArgPos is checked.
+type ParamKind int
+
+const (
+	// ParamAuto checks the variadic elements if the parameter is variadic, and the single parameter otherwise.
+	ParamAuto ParamKind = iota
+	// ParamSingle checks the single parameter. The parameter must not be variadic.
+	ParamSingle
+	// ParamVariadic checks each of the variadic elements. The parameter must be variadic.
+	ParamVariadic
+)
+
+func (k ParamKind) String() string {
+	switch k {
+	case ParamAuto:
+		return "ParamAuto"
+	case ParamSingle:
+		return "ParamSingle"
+	case ParamVariadic:
+		return "ParamVariadic"
+	}
+	return fmt.Sprintf("ParamKind(%d)", int(k))
+}
+
+// InterfacePolicy determines how an argument of interface type which is not allowed is handled.
+type InterfacePolicy int
+
+const (
+	// InterfaceReject reports the argument.
+	InterfaceReject InterfacePolicy = iota
+	// InterfaceAccept accepts the argument.
+	InterfaceAccept
+	// InterfaceNarrowed accepts the argument if the call is dominated by a type switch or a type assertion
+	// which narrows the argument to allowed types.
+	InterfaceNarrowed
+	// InterfaceAssume accepts the argument if the call is annotated with //notany:assume T and T is allowed.
+	// T is a builtin type name or a type name qualified with its package path such as time.Time.
+	InterfaceAssume
+)
+
+// UntypedPolicy determines how an untyped constant argument is checked.
+type UntypedPolicy int
+
+const (
+	// UntypedDefault checks the default type of the constant, e.g., float64 for 1.1.
+	UntypedDefault UntypedPolicy = iota
+	// UntypedRepresentable accepts the constant if it is representable as any of the allowed basic types,
+	// e.g., 1 is accepted if float64 is allowed.
+	UntypedRepresentable
+)
+
+// UntracedPolicy determines how untraced elements are handled.
+type UntracedPolicy int
+
+const (
+	// UntracedReport checks the static element type of the container instead of the elements.
+	UntracedReport UntracedPolicy = iota
+	// UntracedTrust accepts the untraced elements.
+	UntracedTrust
+	// UntracedAnnotated accepts the untraced elements only if the call is annotated with //notany:trust.
+	UntracedAnnotated
+)
+
 // Allowed represents a type that is allowed for the argument.
 type Allowed struct {
 	// The path of the package that defines the type.
 	// If the type is builtin, let it be an empty string.
 	PkgPath string
-	// The name of the type.
+	// The name of the type, or a type expression such as []byte, map[string]*Item, chan<- Event, or Option[int].
+	// Unqualified type names in the expression are resolved in the package of PkgPath and then in the universe,
+	// and qualified ones such as time.Time are resolved by the package names among the analyzed package and its imports.
+	// A type argument * matches any type such as Option[*].
+	// TypeName * matches any named type declared in the package of PkgPath, which must not be empty, and *.* matches the pointers to them.
+	// With them, PkgPath may end with /... to match the subpackages as well.
 	TypeName string
+	// Constraints on the constant values of the type.
+	// If nil, any value is allowed.
+	Values *Values
+	// Methods is the method set which allowed types must have, in place of a named interface.
+	// If Methods is not empty, PkgPath and TypeName are ignored.
+	Methods []Method
+	// Match determines how the type of an argument is matched against the type.
+	// It is ignored for Methods and the wildcard type names.
+	Match MatchMode
+}
+
+// MatchMode determines how the type of an argument is matched against an allowed type.
+type MatchMode int
+
+const (
+	// MatchIdentical accepts the identical type, and the types implementing it if it is an interface.
+	MatchIdentical MatchMode = iota
+	// MatchUnderlying accepts the types whose underlying types are identical to that of the allowed type,
+	// e.g., type MyString string is accepted if string is allowed.
+	MatchUnderlying
+	// MatchAssignable accepts the types assignable to the allowed type.
+	MatchAssignable
+	// MatchConvertible accepts the types convertible to the allowed type.
+	MatchConvertible
+	// MatchExactInterface accepts the interface type itself but not the types implementing it.
+	// The allowed type must be an interface type.
+	MatchExactInterface
+)
+
+// Method is a method required by Allowed.Methods.
+type Method struct {
+	// Name of the method.
+	Name string
+	// Signature of the method written as a function type expression such as `func() ([]byte, error)`.
+	// Types of other packages are qualified with their package names such as `func() slog.Value`,
+	// and the packages are searched among the analyzed package and its imports.
+	// It is an error if the package name is ambiguous, such as rand.Rand with both math/rand and math/rand/v2 imported.
+	Signature string
 }
 
 func (r *runner) run(pass *analysis.Pass) (any, error) {
 	inspect := pass.ResultOf[inspect.Analyzer].(*inspector.Inspector)
-	targets, err := toAnalysisTargets(pass, r.targets)
+	modulePath := modulePathOf(pass)
+	// dependencies analyzed for facts may not reach the allowed types.
+	targets, err := toAnalysisTargets(pass, r.targets, modulePath, r.usesFacts())
 	if err != nil {
 		return nil, err
 	}
-
+	flow := newFlow(pass)
+	consistency := newConsistency()
+	if r.keyDirectives {
+		exportKeyFacts(pass)
+		keys := newKeyIndex(pass)
+		for _, t := range targets {
+			if t.KeyDirectives {
+				t.KeyIndex = keys
+			}
+		}
+	}
+	if r.wrappers {
+		targets = append(targets, factTargets(pass, modulePath)...)
+	}
+	if r.allowedFor {
+		exportAllowedForFacts(pass)
+		targets = addAllowedFor(pass, targets)
+	}
+	if r.wrappers {
+		targets = append(targets, flow.InferWrappers(targets)...)
+	}
+	if r.sensitive {
+		exportSensitiveFacts(pass)
+		sensitive := sensitiveTypes(pass)
+		for _, t := range targets {
+			t.Sensitive = sensitive
+		}
+	}
 	inspect.Preorder(nil, func(n ast.Node) {
 		switch n := n.(type) {
 		case *ast.CallExpr:
-			if result := toBeReported(pass, targets, n); result != nil {
-				pass.Reportf(n.Pos(), "%s is not allowed for the %dth arg of %s", result.ArgType, result.ArgPos+1, result.Func)
+			if result := toBeReported(pass, targets, flow, n); result != nil {
+				switch {
+				case result.Relation != RelationNone:
+					pass.Reportf(n.Pos(), "%s", result.DescribeRelation())
+				case result.UnknownKey:
+					pass.Reportf(n.Pos(), "unknown key %s for the %dth arg of %s", result.Key, result.KeyArgPos+1, result.Func)
+				case result.NonConstKey:
+					pass.Reportf(n.Pos(), "the %dth arg of %s must be a constant key", result.KeyArgPos+1, result.Func)
+				case result.Key != nil:
+					pass.Reportf(n.Pos(), "%s is not allowed for the %dth arg of %s with key %s", result.Describe(), result.ArgPos+1, result.Func, result.Key)
+				case result.Dangling:
+					pass.Reportf(result.ArgExpr.Pos(), "dangling %s at the %dth arg of %s", result.Slot, result.ArgPos+1, result.Func)
+				case result.Slot != "":
+					pass.Reportf(result.ArgExpr.Pos(), "%s is not allowed for the %s at the %dth arg of %s%s", result.Describe(), result.Slot, result.ArgPos+1, result.Func, result.Reason())
+				case result.Origin.IsValid():
+					pass.Reportf(result.Origin, "%s is not allowed for the %dth arg of %s%s", result.Describe(), result.ArgPos+1, result.Func, result.Reason())
+				case result.Elem:
+					pass.Reportf(result.ArgExpr.Pos(), "%s is not allowed for the elements of the %dth arg of %s%s", result.Describe(), result.ArgPos+1, result.Func, result.Reason())
+				case result.Violation != nil:
+					pass.Reportf(n.Pos(), "%s is not allowed for the %dth arg of %s: %s", result.Describe(), result.ArgPos+1, result.Func, result.Violation)
+				case result.PointerHint != nil:
+					pass.Reportf(n.Pos(), "%s is not allowed for the %dth arg of %s: %s implements %s with pointer receivers", result.Describe(), result.ArgPos+1, result.Func, types.NewPointer(result.ArgType), result.PointerHint)
+				default:
+					pass.Reportf(n.Pos(), "%s is not allowed for the %dth arg of %s", result.Describe(), result.ArgPos+1, result.Func)
+				}
+			}
+			for _, result := range nonConstToBeReported(pass, targets, flow, n) {
+				pass.Reportf(result.ArgExpr.Pos(), "the %dth arg of %s must be a compile-time constant", result.ArgPos+1, result.Func)
+			}
+			for _, result := range consistency.Inconsistent(pass, targets, n) {
+				pass.Report(analysis.Diagnostic{
+					Pos:     result.ArgExpr.Pos(),
+					Message: fmt.Sprintf("%s is not allowed for the %dth arg of %s because %s is already passed for %s", result.ArgType, result.ArgPos+1, result.Func, result.FirstType, result.Var.Name()),
+					Related: []analysis.RelatedInformation{
+						{
+							Pos:     result.First,
+							Message: fmt.Sprintf("%s is first passed here", result.FirstType),
+						},
+					},
+				})
+			}
+		case *ast.SendStmt:
+			if result := sendToBeReported(pass, targets, n); result != nil {
+				pass.Reportf(n.Pos(), "%s is not allowed to be sent to %s%s", result.Describe(), result.Var.Name(), result.Reason())
+			}
+		case *ast.AssignStmt, *ast.ValueSpec:
+			for _, result := range assignToBeReported(pass, targets, n) {
+				pass.Reportf(result.ArgExpr.Pos(), "%s is not allowed to be assigned to %s%s", result.Describe(), result.Var.Name(), result.Reason())
 			}
 		}
 	})
@@ -77,47 +412,83 @@ func (r *runner) run(pass *analysis.Pass) (any, error) {
 	return nil, nil
 }
 
-func toAnalysisTargets(pass *analysis.Pass, targets []Target) ([]*analysisTarget, error) {
+// toAnalysisTargets returns the targets resolved in the package.
+// If skipUnresolvable is true, the allowed types not found from the package are ignored instead of being errors.
+func toAnalysisTargets(pass *analysis.Pass, targets []Target, modulePath string, skipUnresolvable bool) ([]*analysisTarget, error) {
 	ret := make([]*analysisTarget, 0, len(targets))
 	for _, t := range targets {
 		t := t
-		ft, err := funcObjectOf(pass, t)
+		list, whole := t.Allowed, t.Whole
+		if skipUnresolvable {
+			list, whole = resolvable(pass, list), resolvable(pass, whole)
+			t.Pattern = resolvableSlots(pass, t.Pattern)
+		}
+		var ft *types.Func
+		var vt *types.Var
+		var err error
+		if t.VarName != "" {
+			vt, err = varObjectOf(pass, t)
+		} else {
+			ft, err = funcObjectOf(pass, t)
+		}
 		if err != nil {
 			if !errors.Is(err, targetNotFound) {
 				return nil, err
 			}
 			continue
 		}
-		allowed := make(map[types.Type]struct{})
-		for _, a := range t.Allowed {
-			if a.PkgPath == "" {
-				typ := types.Universe.Lookup(a.TypeName).Type()
-				allowed[typ] = struct{}{}
-				// builtin alias
-				switch typ {
-				case types.Typ[types.Uint8]:
-					// byteType != types.Typ[types.Byte]
-					allowed[byteType] = struct{}{}
-				case types.Typ[types.Int32]:
-					// runeType != types.Typ[types.Rune]
-					allowed[runeType] = struct{}{}
-				case byteType:
-					allowed[types.Typ[types.Uint8]] = struct{}{}
-				case runeType:
-					allowed[types.Typ[types.Int32]] = struct{}{}
-				}
-				continue
-			}
-			if t := analysisutil.TypeOfBFS(pass.Pkg, a.PkgPath, a.TypeName); t != nil {
-				allowed[t] = struct{}{}
-				continue
-			}
-			return nil, newErrIdentNotFound(pass.Pkg.Path(), a.PkgPath, a.TypeName)
+		allowed, err := toAllowedTypes(pass, list)
+		if err != nil {
+			return nil, err
+		}
+		values, err := toValueConstraints(pass, list)
+		if err != nil {
+			return nil, err
+		}
+		keys, err := toKeyedAllowedTypes(pass, t.Keys, skipUnresolvable)
+		if err != nil {
+			return nil, err
+		}
+		pattern, err := toPatternSlots(pass, t.Pattern)
+		if err != nil {
+			return nil, err
+		}
+		wholeTypes, err := toAllowedTypes(pass, whole)
+		if err != nil {
+			return nil, err
 		}
 		a := &analysisTarget{
-			Func:    ft,
-			ArgPos:  t.ArgPos,
-			Allowed: allowed,
+			Func:          ft,
+			Var:           vt,
+			ArgPos:        t.ArgPos,
+			Param:         t.Param,
+			Elem:          t.Elem,
+			Untraced:      t.Untraced,
+			Flow:          t.Flow,
+			Wrappers:      t.Wrappers,
+			Interface:     t.Interface,
+			ConstOnly:     t.ConstOnly,
+			AllowNil:      t.AllowNil || allowsNil(t.Allowed),
+			Untyped:       t.Untyped,
+			Relation:      t.Relation,
+			RelArgPos:     t.RelatedArgPos,
+			Keyed:         t.Keys != nil || t.KeyDirectives,
+			Keys:          keys,
+			KeyDirectives: t.KeyDirectives,
+			KeyArgPos:     t.KeyArgPos,
+			NonConstKey:   t.NonConstKey,
+			Pattern:       pattern,
+			Whole:         wholeTypes,
+			Predicates:    t.Predicates,
+			MaxSize:       t.MaxSize,
+			Sizes:         pass.TypesSizes,
+			Caller:        pass.Pkg,
+			ModulePath:    modulePath,
+			Consistent:    t.Consistent,
+			AllowedFor:    t.AllowedFor,
+			Allowed:       allowed,
+			Values:        values,
+			AllowedList:   t.Allowed,
 		}
 		if err := a.validate(); err != nil {
 			return nil, err
@@ -127,13 +498,207 @@ func toAnalysisTargets(pass *analysis.Pass, targets []Target) ([]*analysisTarget
 	return ret, nil
 }
 
+// resolvable returns the entries of list except those whose types are not found from the package.
+// No argument in the package can have the types of the excluded entries.
+func resolvable(pass *analysis.Pass, list []Allowed) []Allowed {
+	var ret []Allowed
+	for _, a := range list {
+		if _, err := toAllowedTypes(pass, []Allowed{a}); isNotFound(err) {
+			continue
+		}
+		if _, err := toValueConstraints(pass, []Allowed{a}); isNotFound(err) {
+			continue
+		}
+		ret = append(ret, a)
+	}
+	return ret
+}
+
+func resolvableSlots(pass *analysis.Pass, pattern []Slot) []Slot {
+	if pattern == nil {
+		return nil
+	}
+	ret := make([]Slot, 0, len(pattern))
+	for _, s := range pattern {
+		s.Allowed = resolvable(pass, s.Allowed)
+		ret = append(ret, s)
+	}
+	return ret
+}
+
+func isNotFound(err error) bool {
+	var notFound errIdentNotFound
+	return errors.As(err, &notFound)
+}
+
+func toAllowedTypes(pass *analysis.Pass, list []Allowed) (*allowedTypes, error) {
+	allowed := newAllowedTypes()
+	for _, a := range list {
+		if isNilEntry(a) {
+			// nil is not a type but is allowed by AllowNil.
+			continue
+		}
+		if len(a.Methods) > 0 {
+			ms, err := methodSetOf(pass, a.Methods)
+			if err != nil {
+				return nil, err
+			}
+			allowed.Matchers = append(allowed.Matchers, ms)
+			continue
+		}
+		if a.TypeName == wildcardTypeName || a.TypeName == wildcardPointerTypeName {
+			// the types are matched by their packages without being resolved.
+			p, err := newPackagePattern(a)
+			if err != nil {
+				return nil, err
+			}
+			allowed.Matchers = append(allowed.Matchers, p)
+			continue
+		}
+		typs, m, err := resolveAllowed(pass, a)
+		if err != nil {
+			return nil, err
+		}
+		if m != nil {
+			allowed.Matchers = append(allowed.Matchers, m)
+			continue
+		}
+		for _, typ := range typs {
+			if a.Match == MatchExactInterface && !types.IsInterface(typ) {
+				return nil, newErrNotInterfaceType(a.PkgPath, a.TypeName)
+			}
+			if a.Match != MatchIdentical {
+				allowed.Matchers = append(allowed.Matchers, &matchPattern{typ: typ, mode: a.Match})
+				continue
+			}
+			allowed.Types[typ] = struct{}{}
+		}
+	}
+	return allowed, nil
+}
+
+// isNilEntry reports whether a denotes nil instead of a type.
+func isNilEntry(a Allowed) bool {
+	return a.PkgPath == "" && a.TypeName == "nil" && len(a.Methods) == 0
+}
+
+// allowsNil reports whether any of the entries of list denotes nil.
+func allowsNil(list []Allowed) bool {
+	for _, a := range list {
+		if isNilEntry(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveAllowed returns the types denoted by a.
+// Builtin aliases such as byte are returned together with their original types.
+// If a is a type expression with wildcard type arguments, the matcher of the instances is returned instead.
+func resolveAllowed(pass *analysis.Pass, a Allowed) ([]types.Type, matcher, error) {
+	// pointers to builtin types such as *int are not found in any package, so they are evaluated as type expressions.
+	if !token.IsIdentifier(strings.TrimPrefix(a.TypeName, "*")) || a.PkgPath == "" && strings.HasPrefix(a.TypeName, "*") {
+		typ, m, err := evalTypeExpr(pass, a.PkgPath, a.TypeName)
+		if err != nil {
+			return nil, nil, err
+		}
+		if m != nil {
+			return nil, m, nil
+		}
+		return []types.Type{typ}, nil, nil
+	}
+	if a.PkgPath == "" {
+		obj := types.Universe.Lookup(a.TypeName)
+		if obj == nil {
+			return nil, nil, newErrIdentNotFound(pass.Pkg.Path(), a.PkgPath, a.TypeName)
+		}
+		typ := obj.Type()
+		// builtin alias
+		switch typ {
+		case types.Typ[types.Uint8]:
+			// byteType != types.Typ[types.Byte]
+			return []types.Type{typ, byteType}, nil, nil
+		case types.Typ[types.Int32]:
+			// runeType != types.Typ[types.Rune]
+			return []types.Type{typ, runeType}, nil, nil
+		case byteType:
+			return []types.Type{typ, types.Typ[types.Uint8]}, nil, nil
+		case runeType:
+			return []types.Type{typ, types.Typ[types.Int32]}, nil, nil
+		}
+		return []types.Type{typ}, nil, nil
+	}
+	if t := analysisutil.TypeOfBFS(pass.Pkg, a.PkgPath, a.TypeName); t != nil {
+		return []types.Type{t}, nil, nil
+	}
+	return nil, nil, newErrIdentNotFound(pass.Pkg.Path(), a.PkgPath, a.TypeName)
+}
+
 type analysisTarget struct {
-	Func    *types.Func
-	ArgPos  int
-	Allowed map[types.Type]struct{}
+	Func      *types.Func
+	Var       *types.Var
+	ArgPos    int
+	Param     ParamKind
+	Elem      bool
+	Untraced  UntracedPolicy
+	Flow      bool
+	Wrappers  bool
+	Interface InterfacePolicy
+	ConstOnly bool
+	AllowNil  bool
+	Untyped   UntypedPolicy
+	Relation  Relation
+	RelArgPos int
+	// Keyed is true if the allowed types are selected by the key argument.
+	Keyed bool
+	// Keys maps the exact strings of key constants to the allowed types configured.
+	Keys          map[string]*keyAllowed
+	KeyDirectives bool
+	// KeyIndex is the index of the keys annotated with //notany:key. It is nil unless KeyDirectives is true.
+	KeyIndex    *keyIndex
+	KeyArgPos   int
+	NonConstKey NonConstPolicy
+	Pattern     []*patternSlot
+	Whole       *allowedTypes
+	Predicates  []Predicate
+	MaxSize     int64
+	// Sizes is the sizes of the analyzed platform.
+	Sizes types.Sizes
+	// Caller is the analyzed package, and ModulePath is the path of its module.
+	Caller     *types.Package
+	ModulePath string
+	Consistent bool
+	AllowedFor bool
+	// Wrapped is the target whose policy is inherited if the target is inferred for a wrapper function.
+	// The types annotated with //notany:allowed-for are matched against it.
+	Wrapped *allowedFor
+	// Sensitive is the set of the types annotated with //notany:sensitive.
+	Sensitive map[*types.TypeName]bool
+	Allowed   *allowedTypes
+	// Values is the value constraints for the allowed types.
+	Values []*valueConstraint
+	// AllowedList is the list from which Allowed is built.
+	AllowedList []Allowed
+}
+
+// typeChecked reports whether the types of the arguments are checked.
+// A target only for the consistency or the constness allows any type unless types are configured.
+func (a *analysisTarget) typeChecked() bool {
+	if !a.Consistent && !a.ConstOnly {
+		return true
+	}
+	return len(a.AllowedList) > 0 || !a.Allowed.Empty() || a.constrained() ||
+		a.Keyed || a.Relation != RelationNone || len(a.Pattern) > 0
 }
 
 func (a *analysisTarget) validate() error {
+	if a.Var != nil {
+		switch a.Var.Type().Underlying().(type) {
+		case *types.Chan, *types.Interface:
+			return nil
+		}
+		return newErrNotVar(a.Var.Pkg().Path(), a.Var.Name())
+	}
 	if a.Func == nil || a.Func == (*types.Func)(nil) {
 		return nil
 	}
@@ -144,21 +709,102 @@ func (a *analysisTarget) validate() error {
 	if sig.Params().Len() <= a.ArgPos {
 		return newErrArgPosOutOfRange(a.Func.Pkg().Path(), a.Func.Name(), a.ArgPos)
 	}
+	if a.Relation != RelationNone && sig.Params().Len() <= a.RelArgPos {
+		return newErrArgPosOutOfRange(a.Func.Pkg().Path(), a.Func.Name(), a.RelArgPos)
+	}
+	if a.Keyed && sig.Params().Len() <= a.KeyArgPos {
+		return newErrArgPosOutOfRange(a.Func.Pkg().Path(), a.Func.Name(), a.KeyArgPos)
+	}
+	if first, second := a.conflict(); first != "" {
+		return newErrConflictingFields(a.Func.Pkg().Path(), a.Func.Name(), a.ArgPos, first, second)
+	}
+	if a.Wrappers && (a.Keyed || a.Relation != RelationNone || len(a.Pattern) > 0 || a.Elem) {
+		return newErrWrappersNotSupported(a.Func.Pkg().Path(), a.Func.Name(), a.ArgPos)
+	}
+	variadic := isVariadicParam(sig, a.ArgPos)
+	switch {
+	case a.Param == ParamSingle && variadic,
+		a.Param == ParamVariadic && !variadic,
+		len(a.Pattern) > 0 && !variadic,
+		a.Elem && variadic:
+		return newErrParamKindMismatch(a.Func.Pkg().Path(), a.Func.Name(), a.ArgPos, a.Param)
+	}
+	typ := sig.Params().At(a.ArgPos).Type()
+	if variadic {
+		typ = typ.(*types.Slice).Elem()
+	}
+	if a.Elem {
+		typ = elemTypeOf(typ)
+		if typ == nil {
+			return newErrNotContainer(a.Func.Pkg().Path(), a.Func.Name(), a.ArgPos)
+		}
+	}
+	if !types.IsInterface(typ) {
+		return newErrNotInterface(a.Func.Pkg().Path(), a.Func.Name(), a.ArgPos)
+	}
 	return nil
 }
 
-func (a *analysisTarget) Allow(t types.Type) bool {
-	if _, ok := a.Allowed[t]; ok {
-		return true
+// conflict returns the names of two fields of Target which cannot be combined, or empty strings if there is none.
+// Relation, Keys and Pattern select the allowed types by themselves, so they would otherwise silently drop the others.
+func (a *analysisTarget) conflict() (string, string) {
+	var kinds []string
+	if a.Relation != RelationNone {
+		kinds = append(kinds, "Relation")
 	}
-	for at := range a.Allowed {
-		if i, ok := at.Underlying().(*types.Interface); ok {
-			if types.Implements(t, i) {
-				return true
-			}
+	if a.Keyed {
+		kinds = append(kinds, "Keys")
+	}
+	if len(a.Pattern) > 0 {
+		kinds = append(kinds, "Pattern")
+	}
+	switch {
+	case len(kinds) > 1:
+		return kinds[0], kinds[1]
+	case len(kinds) == 0:
+		return "", ""
+	case len(a.AllowedList) > 0:
+		return kinds[0], "Allowed"
+	case kinds[0] == "Relation" && len(a.Predicates) > 0:
+		return kinds[0], "Predicates"
+	case kinds[0] == "Relation" && a.MaxSize > 0:
+		return kinds[0], "MaxSize"
+	}
+	return "", ""
+}
+
+// isVariadicParam reports whether the parameter at pos of sig is variadic.
+func isVariadicParam(sig *types.Signature, pos int) bool {
+	return sig.Variadic() && pos == sig.Params().Len()-1
+}
+
+// AllowValue reports whether the constant value val of the allowed type typ is allowed.
+// val is nil if the argument is not a constant.
+// It is allowed if any of the entries allowing typ allows val, or if typ is allowed only by other means such as //notany:allowed-for.
+func (a *analysisTarget) AllowValue(typ types.Type, val constant.Value) bool {
+	constrained := false
+	for _, vc := range a.Values {
+		if !vc.types.Allow(typ) {
+			continue
 		}
+		if vc.Allow(val) {
+			return true
+		}
+		constrained = true
 	}
-	return false
+	return !constrained
+}
+
+func (a *analysisTarget) Allow(t types.Type) bool {
+	if a.constrained() {
+		if a.violationOf(t) != nil {
+			return false
+		}
+		if len(a.AllowedList) == 0 {
+			return true
+		}
+	}
+	return a.Allowed.Allow(t)
 }
 
 var byteType = types.Universe.Lookup("byte").Type()
@@ -197,52 +843,146 @@ func funcObjectOf(pass *analysis.Pass, t Target) (*types.Func, error) {
 	return m, nil
 }
 
+func varObjectOf(pass *analysis.Pass, t Target) (*types.Var, error) {
+	// package-level variable
+	if !strings.Contains(t.VarName, ".") {
+		obj := analysisutil.ObjectOf(pass, t.PkgPath, t.VarName)
+		if obj == nil {
+			// not found is ok because variable need not to be used.
+			return nil, targetNotFound
+		}
+		vt, ok := obj.(*types.Var)
+		if !ok {
+			return nil, newErrNotVar(t.PkgPath, t.VarName)
+		}
+		return vt, nil
+	}
+	tt := strings.Split(t.VarName, ".")
+	if len(tt) != 2 {
+		return nil, newErrInvalidFuncName(t.VarName)
+	}
+	// field
+	recv := tt[0]
+	field := tt[1]
+	recvType := analysisutil.TypeOf(pass, t.PkgPath, recv)
+	if recvType == nil {
+		// not found is ok because field need not to be used.
+		return nil, targetNotFound
+	}
+	obj, _, _ := types.LookupFieldOrMethod(recvType, true, nil, field)
+	vt, ok := obj.(*types.Var)
+	if !ok || !vt.IsField() {
+		return nil, newErrNotVar(t.PkgPath, t.VarName)
+	}
+	return vt, nil
+}
+
 // toBeReported reports whether the call expression n should be reported.
 // If nill is returned, it means that n should not be reported.
-func toBeReported(pass *analysis.Pass, targets []*analysisTarget, n *ast.CallExpr) *notAllowed {
-	switch f := n.Fun.(type) {
-	case *ast.Ident:
-		return x(pass, targets, n, f)
-	case *ast.SelectorExpr:
-		return x(pass, targets, n, f.Sel)
+func toBeReported(pass *analysis.Pass, targets []*analysisTarget, flow *flow, n *ast.CallExpr) *notAllowed {
+	obj := calleeOf(pass, n)
+	if obj == nil {
+		return nil
 	}
-	return nil
+	return x(pass, targets, flow, n, obj)
 }
 
-func x(pass *analysis.Pass, targets []*analysisTarget, n *ast.CallExpr, f *ast.Ident) *notAllowed {
-	obj, ok := pass.TypesInfo.ObjectOf(f).(*types.Func)
-	if !ok {
+// calleeOf returns the function or method called by n.
+// nil is returned if the callee is not a named function or method.
+func calleeOf(pass *analysis.Pass, n *ast.CallExpr) *types.Func {
+	var f *ast.Ident
+	switch fun := n.Fun.(type) {
+	case *ast.Ident:
+		f = fun
+	case *ast.SelectorExpr:
+		f = fun.Sel
+	default:
 		return nil
 	}
+	obj, _ := pass.TypesInfo.ObjectOf(f).(*types.Func)
+	return obj
+}
+
+func x(pass *analysis.Pass, targets []*analysisTarget, flow *flow, n *ast.CallExpr, obj *types.Func) *notAllowed {
 	sig, _ := obj.Type().(*types.Signature)
+	args := callArgsOf(pass, n)
 	for _, t := range targets {
 		if t.Func != obj {
 			continue
 		}
+		if flow.Forwarded(n, t) {
+			// checked at the call sites of the wrapper function.
+			continue
+		}
+		if result := sensitiveNotAllowed(t, sig, args); result != nil {
+			result.Func = obj
+			return result
+		}
+		variadic := isVariadicParam(sig, t.ArgPos)
 		switch {
-		case !sig.Variadic():
-			arg := n.Args[t.ArgPos]
-			argType := pass.TypesInfo.Types[arg].Type
-			if !t.Allow(argType) {
-				return &notAllowed{
-					ArgExpr: arg,
-					ArgType: argType,
-					ArgPos:  t.ArgPos,
-					Func:    obj,
+		case !t.typeChecked():
+			// only the consistency or the constness is checked.
+			continue
+		case t.Relation != RelationNone:
+			if result := relationNotAllowed(pass, t, args); result != nil {
+				result.Func = obj
+				return result
+			}
+			continue
+		case t.Keyed:
+			if result := keyNotAllowed(pass, t, n, args); result != nil {
+				result.Func = obj
+				return result
+			}
+			continue
+		case len(t.Pattern) > 0:
+			if result := patternNotAllowed(pass, t, n, args); result != nil {
+				result.Func = obj
+				return result
+			}
+			continue
+		case t.Elem:
+			if t.ArgPos >= len(args) {
+				continue
+			}
+			arg := args[t.ArgPos]
+			if result := elemNotAllowed(pass, t, n, arg.Expr, arg.Type); result != nil {
+				result.ArgPos = t.ArgPos
+				result.Func = obj
+				return result
+			}
+			continue
+		case !variadic:
+			if t.ArgPos >= len(args) {
+				continue
+			}
+			arg := args[t.ArgPos]
+			if t.Flow && types.IsInterface(arg.Type) && (len(t.Sensitive) > 0 || !allowArg(pass, t, n, arg)) {
+				// the dynamic types are traced even if the interface is allowed, so that sensitive values are detected.
+				if result := flowNotAllowed(pass, flow, t, n, arg, sig); result != nil {
+					result.Func = obj
+					return result
 				}
+				continue
+			}
+			if !allowArg(pass, t, n, arg) {
+				return argNotAllowed(pass, t, arg, t.ArgPos, obj)
 			}
 			continue
-		case sig.Variadic():
-			for p := t.ArgPos; p < len(n.Args); p++ {
-				arg := n.Args[p]
-				argType := pass.TypesInfo.Types[arg].Type
-				if !t.Allow(argType) {
-					return &notAllowed{
-						ArgExpr: arg,
-						ArgType: argType,
-						ArgPos:  p,
-						Func:    obj,
+		case variadic:
+			for p := t.ArgPos; p < len(args); p++ {
+				arg := args[p]
+				if n.Ellipsis.IsValid() && p == len(args)-1 {
+					// spread argument (args...)
+					if result := elemNotAllowed(pass, t, n, arg.Expr, arg.Type); result != nil {
+						result.ArgPos = p
+						result.Func = obj
+						return result
 					}
+					continue
+				}
+				if !allowArg(pass, t, n, arg) {
+					return argNotAllowed(pass, t, arg, p, obj)
 				}
 			}
 			continue
@@ -251,6 +991,231 @@ func x(pass *analysis.Pass, targets []*analysisTarget, n *ast.CallExpr, f *ast.I
 	return nil
 }
 
+// checkArg returns the result if the value arg passed through n is not allowed by t.
+// n is the call or the statement through which arg is passed.
+// Besides the allowed types of t, the sensitive types and the policies for nil, untyped constants,
+// constant values and interfaces are applied, so that all the values reaching the targets are checked in the same way.
+// If nil is returned, it means that arg is allowed.
+func checkArg(pass *analysis.Pass, t *analysisTarget, n ast.Node, arg callArg, argPos int) *notAllowed {
+	if v := sensitiveOf(arg.Type, t.Sensitive); v != nil {
+		return &notAllowed{
+			ArgExpr:   arg.Expr,
+			ArgType:   arg.Type,
+			ArgPos:    argPos,
+			Violation: v,
+		}
+	}
+	if allowArg(pass, t, n, arg) {
+		return nil
+	}
+	return argNotAllowed(pass, t, arg, argPos, nil)
+}
+
+// allowArg reports whether the argument arg passed through n is allowed by t.
+func allowArg(pass *analysis.Pass, t *analysisTarget, n ast.Node, arg callArg) bool {
+	if isNilArg(pass, arg) {
+		return t.AllowNil
+	}
+	if t.nilPointerViolation(pass, arg.Expr) != nil {
+		return false
+	}
+	if t.Allow(arg.Type) {
+		return t.AllowValue(arg.Type, pass.TypesInfo.Types[arg.Expr].Value)
+	}
+	if t.Untyped == UntypedRepresentable && allowUntyped(pass, t, arg) {
+		return true
+	}
+	return allowInterface(pass, t, n, arg)
+}
+
+func argNotAllowed(pass *analysis.Pass, t *analysisTarget, arg callArg, argPos int, fn *types.Func) *notAllowed {
+	ret := &notAllowed{
+		ArgExpr: arg.Expr,
+		ArgType: arg.Type,
+		ArgPos:  argPos,
+		Func:    fn,
+	}
+	if isNilArg(pass, arg) {
+		ret.Nil = true
+	}
+	if v := t.nilPointerViolation(pass, arg.Expr); v != nil {
+		ret.Violation = v
+		return ret
+	}
+	if t.Allow(arg.Type) {
+		// the type is allowed but the value is not.
+		ret.BadValue = true
+		ret.Value = pass.TypesInfo.Types[arg.Expr].Value
+		return ret
+	}
+	if isUntypedConst(pass, arg.Expr) {
+		ret.Value = pass.TypesInfo.Types[arg.Expr].Value
+	}
+	if arg.Type != nil && t.constrained() {
+		ret.Violation = t.violationOf(arg.Type)
+	}
+	ret.PointerHint = pointerImplements(t, arg.Type)
+	return ret
+}
+
+// relationNotAllowed returns the result if the arguments of the call do not satisfy the relationship required by t.
+func relationNotAllowed(pass *analysis.Pass, t *analysisTarget, args []callArg) *notAllowed {
+	if t.ArgPos >= len(args) || t.RelArgPos >= len(args) {
+		return nil
+	}
+	arg, rel := args[t.ArgPos], args[t.RelArgPos]
+	if isNil(pass, arg.Expr) || isNil(pass, rel.Expr) || arg.Type == nil || rel.Type == nil {
+		return nil
+	}
+	switch t.Relation {
+	case RelationIdentical:
+		if types.Identical(arg.Type, rel.Type) {
+			return nil
+		}
+	case RelationAssignable:
+		if types.AssignableTo(arg.Type, rel.Type) {
+			return nil
+		}
+	}
+	return &notAllowed{
+		ArgExpr:    arg.Expr,
+		ArgType:    arg.Type,
+		ArgPos:     t.ArgPos,
+		Relation:   t.Relation,
+		RelArgPos:  t.RelArgPos,
+		RelArgType: rel.Type,
+	}
+}
+
+func isNil(pass *analysis.Pass, expr ast.Expr) bool {
+	tv, ok := pass.TypesInfo.Types[expr]
+	return ok && tv.IsNil()
+}
+
+// isNilArg reports whether arg is nil, including the zero elements of containers.
+func isNilArg(pass *analysis.Pass, arg callArg) bool {
+	return isNil(pass, arg.Expr) || arg.Type == types.Typ[types.UntypedNil]
+}
+
+type callArg struct {
+	Expr ast.Expr
+	Type types.Type
+}
+
+// callArgsOf returns the arguments of the call n.
+// If n is in the form f(g()) and g returns multiple values, each of the values is an argument whose Expr is g().
+func callArgsOf(pass *analysis.Pass, n *ast.CallExpr) []callArg {
+	return valuesOf(pass, n.Args)
+}
+
+// valuesOf returns the values of the expressions.
+// If exprs is a single expression of multiple values such as g() or m[k] in the comma-ok form,
+// each of the values has the expression as Expr.
+func valuesOf(pass *analysis.Pass, exprs []ast.Expr) []callArg {
+	if len(exprs) == 1 {
+		if tuple, ok := pass.TypesInfo.Types[exprs[0]].Type.(*types.Tuple); ok {
+			ret := make([]callArg, 0, tuple.Len())
+			for i := 0; i < tuple.Len(); i++ {
+				ret = append(ret, callArg{
+					Expr: exprs[0],
+					Type: tuple.At(i).Type(),
+				})
+			}
+			return ret
+		}
+	}
+	ret := make([]callArg, 0, len(exprs))
+	for _, e := range exprs {
+		ret = append(ret, callArg{
+			Expr: e,
+			Type: pass.TypesInfo.Types[e].Type,
+		})
+	}
+	return ret
+}
+
+// sendToBeReported reports whether the send statement n should be reported.
+// If nil is returned, it means that n should not be reported.
+func sendToBeReported(pass *analysis.Pass, targets []*analysisTarget, n *ast.SendStmt) *notAllowed {
+	v := varOf(pass, n.Chan)
+	if v == nil {
+		return nil
+	}
+	for _, t := range targets {
+		if t.Var != v {
+			continue
+		}
+		arg := callArg{
+			Expr: n.Value,
+			Type: pass.TypesInfo.Types[n.Value].Type,
+		}
+		if result := checkArg(pass, t, n, arg, 0); result != nil {
+			result.Var = v
+			return result
+		}
+	}
+	return nil
+}
+
+// assignToBeReported returns the values assigned to the variables in n that should be reported.
+// n is an assignment statement or a variable declaration with initial values.
+func assignToBeReported(pass *analysis.Pass, targets []*analysisTarget, n ast.Node) []*notAllowed {
+	var lhs, rhs []ast.Expr
+	switch n := n.(type) {
+	case *ast.AssignStmt:
+		if n.Tok != token.ASSIGN {
+			return nil
+		}
+		lhs, rhs = n.Lhs, n.Rhs
+	case *ast.ValueSpec:
+		for _, name := range n.Names {
+			lhs = append(lhs, name)
+		}
+		rhs = n.Values
+	}
+	values := valuesOf(pass, rhs)
+	if len(values) != len(lhs) {
+		// variables declared without initial values
+		return nil
+	}
+	var ret []*notAllowed
+	for i, l := range lhs {
+		v := varOf(pass, l)
+		if v == nil {
+			continue
+		}
+		if _, ok := v.Type().Underlying().(*types.Chan); ok {
+			// assigning a channel itself is not checked.
+			continue
+		}
+		for _, t := range targets {
+			if t.Var != v {
+				continue
+			}
+			if result := checkArg(pass, t, n, values[i], 0); result != nil {
+				result.Var = v
+				ret = append(ret, result)
+				break
+			}
+		}
+	}
+	return ret
+}
+
+// varOf returns the variable denoted by expr.
+// If expr does not denote a variable, nil is returned.
+func varOf(pass *analysis.Pass, expr ast.Expr) *types.Var {
+	switch e := astutil.Unparen(expr).(type) {
+	case *ast.Ident:
+		v, _ := pass.TypesInfo.ObjectOf(e).(*types.Var)
+		return v
+	case *ast.SelectorExpr:
+		v, _ := pass.TypesInfo.ObjectOf(e.Sel).(*types.Var)
+		return v
+	}
+	return nil
+}
+
 var targetNotFound = errors.New("target not found")
 
 type notAllowed struct {
@@ -258,6 +1223,77 @@ type notAllowed struct {
 	ArgType types.Type
 	ArgPos  int
 	Func    *types.Func
+	Var     *types.Var
+	// Elem is true if ArgExpr is an element of the argument.
+	Elem bool
+	// Origin is the position where ArgType flows into the argument.
+	// It is token.NoPos unless the target is checked with data flow.
+	Origin token.Pos
+	// Nil is true if ArgExpr is nil.
+	Nil bool
+	// Value is the value of ArgExpr if it is an untyped constant or BadValue is true.
+	Value constant.Value
+	// BadValue is true if ArgType is allowed but the value is not.
+	BadValue bool
+	// Relation is the relationship which ArgType and RelArgType do not satisfy.
+	Relation   Relation
+	RelArgPos  int
+	RelArgType types.Type
+	// Key is the value of the key argument at KeyArgPos which selects the allowed types.
+	Key       constant.Value
+	KeyArgPos int
+	// UnknownKey is true if Key is not a known key.
+	UnknownKey bool
+	// NonConstKey is true if the key argument is not a constant.
+	NonConstKey bool
+	// Slot is the name of the slot of the pattern at which ArgExpr is.
+	Slot string
+	// Dangling is true if ArgExpr is the last argument of an incomplete repetition of the pattern.
+	Dangling bool
+	// Violation is the part of ArgType which does not satisfy the predicates of the target.
+	Violation *violation
+	// First is the position of the argument first passed for Var, and FirstType is its type,
+	// which is inconsistent with ArgType.
+	First     token.Pos
+	FirstType types.Type
+	// PointerHint is the allowed interface which is implemented not by ArgType but by the pointer to ArgType.
+	PointerHint fmt.Stringer
+}
+
+// DescribeRelation returns the description of the relationship which the arguments do not satisfy.
+func (r *notAllowed) DescribeRelation() string {
+	if r.Relation == RelationAssignable {
+		return fmt.Sprintf("the %dth arg (%s) of %s must be assignable to the %dth arg (%s)", r.ArgPos+1, r.ArgType, r.Func, r.RelArgPos+1, r.RelArgType)
+	}
+	return fmt.Sprintf("the %dth arg (%s) and the %dth arg (%s) of %s must be %s", r.ArgPos+1, r.ArgType, r.RelArgPos+1, r.RelArgType, r.Func, r.Relation)
+}
+
+// Reason returns the reason why ArgType is not allowed prefixed with a colon, or an empty string if there is no specific reason.
+func (r *notAllowed) Reason() string {
+	if r.Violation == nil {
+		return ""
+	}
+	return ": " + r.Violation.String()
+}
+
+// Describe returns the description of the argument which is not allowed.
+func (r *notAllowed) Describe() string {
+	switch {
+	case r.Nil:
+		return "nil"
+	case r.BadValue && r.Value == nil:
+		return fmt.Sprintf("%s (non-constant)", r.ArgType)
+	case r.BadValue:
+		return fmt.Sprintf("%s (value %s)", r.ArgType, r.Value)
+	case r.Value != nil:
+		// the value is printed as well unless the source is a literal such as 1.0 or true.
+		src := types.ExprString(r.ArgExpr)
+		if _, ok := astutil.Unparen(r.ArgExpr).(*ast.BasicLit); ok || src == r.Value.String() {
+			return fmt.Sprintf("%s (untyped constant %s)", r.ArgType, src)
+		}
+		return fmt.Sprintf("%s (untyped constant %s = %s)", r.ArgType, src, r.Value)
+	}
+	return r.ArgType.String()
 }
 
 type errArgPosOutOfRange struct {
@@ -310,6 +1346,24 @@ func (e errIdentNotFound) Error() string {
 	return fmt.Sprintf("%[1]s.%[2]s is not found in %[3]s or its imports. Import %[1]s to %[3]s", e.PkgPath, e.Name, e.FromPkgPath)
 }
 
+type errAmbiguousPkgName struct {
+	FromPkgPath string
+	PkgName     string
+	Name        string
+}
+
+func newErrAmbiguousPkgName(fromPkgPath, pkgName, name string) errAmbiguousPkgName {
+	return errAmbiguousPkgName{
+		FromPkgPath: fromPkgPath,
+		PkgName:     pkgName,
+		Name:        name,
+	}
+}
+
+func (e errAmbiguousPkgName) Error() string {
+	return fmt.Sprintf("%[1]s.%[2]s is ambiguous because more than one package named %[1]s in %[3]s or its imports declares %[2]s", e.PkgName, e.Name, e.FromPkgPath)
+}
+
 type errNotFunc struct {
 	PkgPath  string
 	FuncName string
@@ -343,3 +1397,178 @@ func newErrNotMethod(pkgPath, recv, methodName string) errNotMethod {
 func (e errNotMethod) Error() string {
 	return fmt.Sprintf("%s.%s.%s is not a method.", e.PkgPath, e.MethodName, e.Recv)
 }
+
+type errNotVar struct {
+	PkgPath string
+	VarName string
+}
+
+func newErrNotVar(pkgPath, varName string) errNotVar {
+	return errNotVar{
+		PkgPath: pkgPath,
+		VarName: varName,
+	}
+}
+
+func (e errNotVar) Error() string {
+	return fmt.Sprintf("%s.%s is not a variable of interface or channel type.", e.PkgPath, e.VarName)
+}
+
+type errNotContainer struct {
+	PkgPath  string
+	FuncName string
+	ArgPos   int
+}
+
+func newErrNotContainer(pkgPath, funcName string, argPos int) errNotContainer {
+	return errNotContainer{
+		PkgPath:  pkgPath,
+		FuncName: funcName,
+		ArgPos:   argPos,
+	}
+}
+
+func (e errNotContainer) Error() string {
+	return fmt.Sprintf("ArgPos %d of %s.%s is not a slice, an array, or a map", e.ArgPos, e.PkgPath, e.FuncName)
+}
+
+type errParamKindMismatch struct {
+	PkgPath  string
+	FuncName string
+	ArgPos   int
+	Param    ParamKind
+}
+
+func newErrParamKindMismatch(pkgPath, funcName string, argPos int, param ParamKind) errParamKindMismatch {
+	return errParamKindMismatch{
+		PkgPath:  pkgPath,
+		FuncName: funcName,
+		ArgPos:   argPos,
+		Param:    param,
+	}
+}
+
+func (e errParamKindMismatch) Error() string {
+	return fmt.Sprintf("ArgPos %d of %s.%s does not match %s", e.ArgPos, e.PkgPath, e.FuncName, e.Param)
+}
+
+type errNotInterface struct {
+	PkgPath  string
+	FuncName string
+	ArgPos   int
+}
+
+func newErrNotInterface(pkgPath, funcName string, argPos int) errNotInterface {
+	return errNotInterface{
+		PkgPath:  pkgPath,
+		FuncName: funcName,
+		ArgPos:   argPos,
+	}
+}
+
+func (e errNotInterface) Error() string {
+	return fmt.Sprintf("ArgPos %d of %s.%s is not of interface type", e.ArgPos, e.PkgPath, e.FuncName)
+}
+
+type errInvalidConst struct {
+	Expr string
+}
+
+func newErrInvalidConst(expr string) errInvalidConst {
+	return errInvalidConst{
+		Expr: expr,
+	}
+}
+
+func (e errInvalidConst) Error() string {
+	return fmt.Sprintf("%s is not a valid constant expression", e.Expr)
+}
+
+type errInvalidTypeExpr struct {
+	Expr string
+}
+
+func newErrInvalidTypeExpr(expr string) errInvalidTypeExpr {
+	return errInvalidTypeExpr{
+		Expr: expr,
+	}
+}
+
+func (e errInvalidTypeExpr) Error() string {
+	return fmt.Sprintf("%s is not a valid type expression", e.Expr)
+}
+
+type errNotInterfaceType struct {
+	PkgPath  string
+	TypeName string
+}
+
+func newErrNotInterfaceType(pkgPath, typeName string) errNotInterfaceType {
+	return errNotInterfaceType{
+		PkgPath:  pkgPath,
+		TypeName: typeName,
+	}
+}
+
+func (e errNotInterfaceType) Error() string {
+	if e.PkgPath == "" {
+		return fmt.Sprintf("%s is not an interface type but MatchExactInterface is specified.", e.TypeName)
+	}
+	return fmt.Sprintf("%s.%s is not an interface type but MatchExactInterface is specified.", e.PkgPath, e.TypeName)
+}
+
+type errWildcardWithoutPkgPath struct {
+	PkgPath  string
+	TypeName string
+}
+
+func newErrWildcardWithoutPkgPath(pkgPath, typeName string) errWildcardWithoutPkgPath {
+	return errWildcardWithoutPkgPath{
+		PkgPath:  pkgPath,
+		TypeName: typeName,
+	}
+}
+
+func (e errWildcardWithoutPkgPath) Error() string {
+	return fmt.Sprintf("the wildcard type name %q requires a package path but got %q", e.TypeName, e.PkgPath)
+}
+
+type errWrappersNotSupported struct {
+	PkgPath  string
+	FuncName string
+	ArgPos   int
+}
+
+func newErrWrappersNotSupported(pkgPath, funcName string, argPos int) errWrappersNotSupported {
+	return errWrappersNotSupported{
+		PkgPath:  pkgPath,
+		FuncName: funcName,
+		ArgPos:   argPos,
+	}
+}
+
+func (e errWrappersNotSupported) Error() string {
+	return fmt.Sprintf("Wrappers cannot be combined with Keys, KeyDirectives, Relation, Pattern, or Elem for ArgPos %d of %s.%s", e.ArgPos, e.PkgPath, e.FuncName)
+}
+
+type errConflictingFields struct {
+	PkgPath  string
+	FuncName string
+	ArgPos   int
+	First    string
+	Second   string
+}
+
+func newErrConflictingFields(pkgPath, funcName string, argPos int, first, second string) errConflictingFields {
+	return errConflictingFields{
+		PkgPath:  pkgPath,
+		FuncName: funcName,
+		ArgPos:   argPos,
+		First:    first,
+		Second:   second,
+	}
+}
+
+func (e errConflictingFields) Error() string {
+	return fmt.Sprintf("%s cannot be combined with %s for ArgPos %d of %s.%s", e.First, e.Second, e.ArgPos, e.PkgPath, e.FuncName)
+}
@@ -361,6 +361,1831 @@ func TestAnalyzer_target_unused(t *testing.T) {
 		"unused")
 }
 
+func TestAnalyzer_var(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath: "vars",
+			VarName: "Events",
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath: "vars",
+			VarName: "Bus.Events",
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "vars",
+					TypeName: "MyInt",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "vars",
+			VarName:  "Hook",
+			AllowNil: true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath: "vars",
+			VarName: "Init",
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath: "vars",
+			VarName: "Floats",
+			Untyped: notany.UntypedRepresentable,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "float64",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath: "vars",
+			VarName: "Level",
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+					Values: &notany.Values{
+						Consts: []string{`"debug"`, `"info"`},
+					},
+				},
+			},
+		},
+	), "vars")
+}
+
+func TestAnalyzer_not_var(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	treporter := NewAnalysisErrorReporter(1)
+	analysistest.Run(treporter, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath: "notvar",
+			VarName: "Count",
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+			},
+		}), "notvar")
+	errs := treporter.Errors()
+	want := notany.ErrNotVar{
+		PkgPath: "notvar",
+		VarName: "Count",
+	}
+	if len(errs) != 1 {
+		t.Fatalf("err expected but not found: %v", want)
+	}
+	if !errors.Is(errs[0], want) {
+		t.Errorf("got %v, want %v", errs[0], want)
+	}
+}
+
+func TestAnalyzer_elem(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "elem",
+			FuncName: "Exec",
+			ArgPos:   1,
+			Elem:     true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "elem",
+			FuncName: "ExecArray",
+			ArgPos:   0,
+			Elem:     true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "elem",
+			FuncName: "WithFields",
+			ArgPos:   0,
+			Elem:     true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "elem",
+			FuncName: "ExecTyped",
+			ArgPos:   0,
+			Elem:     true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "elem",
+			FuncName: "WithTypedFields",
+			ArgPos:   0,
+			Elem:     true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+	), "elem")
+}
+
+func TestAnalyzer_not_container(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	treporter := NewAnalysisErrorReporter(1)
+	analysistest.Run(treporter, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "oor",
+			FuncName: "OutOfRange",
+			ArgPos:   0,
+			Elem:     true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+			},
+		}), "oor")
+	errs := treporter.Errors()
+	want := notany.ErrNotContainer{
+		PkgPath:  "oor",
+		FuncName: "OutOfRange",
+		ArgPos:   0,
+	}
+	if len(errs) != 1 {
+		t.Fatalf("err expected but not found: %v", want)
+	}
+	if !errors.Is(errs[0], want) {
+		t.Errorf("got %v, want %v", errs[0], want)
+	}
+}
+
+func TestAnalyzer_spread(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "spread",
+			FuncName: "Report",
+			ArgPos:   0,
+			Untraced: notany.UntracedReport,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "spread",
+			FuncName: "Trust",
+			ArgPos:   0,
+			Untraced: notany.UntracedTrust,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "spread",
+			FuncName: "Annotated",
+			ArgPos:   0,
+			Untraced: notany.UntracedAnnotated,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+	), "spread")
+}
+
+func TestAnalyzer_tuple(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "tuple",
+			FuncName: "Target",
+			ArgPos:   1,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "tuple",
+			FuncName: "Variadic",
+			ArgPos:   1,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+	), "tuple")
+}
+
+func TestAnalyzer_param(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "param",
+			FuncName: "Log",
+			ArgPos:   0,
+			Param:    notany.ParamSingle,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "param",
+			FuncName: "Tail",
+			ArgPos:   1,
+			Param:    notany.ParamVariadic,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+	), "param")
+}
+
+func TestAnalyzer_param_kind_mismatch(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	treporter := NewAnalysisErrorReporter(1)
+	analysistest.Run(treporter, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "param",
+			FuncName: "Log",
+			ArgPos:   0,
+			Param:    notany.ParamVariadic,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+			},
+		}), "param")
+	errs := treporter.Errors()
+	want := notany.ErrParamKindMismatch{
+		PkgPath:  "param",
+		FuncName: "Log",
+		ArgPos:   0,
+		Param:    notany.ParamVariadic,
+	}
+	if len(errs) != 1 {
+		t.Fatalf("err expected but not found: %v", want)
+	}
+	if !errors.Is(errs[0], want) {
+		t.Errorf("got %v, want %v", errs[0], want)
+	}
+}
+
+func TestAnalyzer_not_interface(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	treporter := NewAnalysisErrorReporter(1)
+	analysistest.Run(treporter, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "param",
+			FuncName: "Concrete",
+			ArgPos:   0,
+			Param:    notany.ParamAuto,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+			},
+		}), "param")
+	errs := treporter.Errors()
+	want := notany.ErrNotInterface{
+		PkgPath:  "param",
+		FuncName: "Concrete",
+		ArgPos:   0,
+	}
+	if len(errs) != 1 {
+		t.Fatalf("err expected but not found: %v", want)
+	}
+	if !errors.Is(errs[0], want) {
+		t.Errorf("got %v, want %v", errs[0], want)
+	}
+}
+
+func TestAnalyzer_flow(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "flow",
+			FuncName: "Target",
+			ArgPos:   0,
+			Flow:     true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+				{
+					PkgPath:  "flow",
+					TypeName: "str",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "flow",
+			FuncName: "NoFlow",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+				{
+					PkgPath:  "flow",
+					TypeName: "str",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "flow",
+			FuncName: "TargetNil",
+			ArgPos:   0,
+			Flow:     true,
+			AllowNil: true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "flow",
+			FuncName: "Level",
+			ArgPos:   0,
+			Flow:     true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+					Values: &notany.Values{
+						Consts: []string{`"debug"`, `"info"`},
+					},
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "flow",
+			FuncName: "Float",
+			ArgPos:   0,
+			Flow:     true,
+			Untyped:  notany.UntypedRepresentable,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "float64",
+				},
+			},
+		},
+	), "flow")
+}
+
+func TestAnalyzer_wrappers(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "wrapper/base",
+			FuncName: "Info",
+			ArgPos:   1,
+			Wrappers: true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "wrapper/base",
+			FuncName: "Nilable",
+			ArgPos:   0,
+			Wrappers: true,
+			AllowNil: true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:   "wrapper/base",
+			FuncName:  "Const",
+			ArgPos:    0,
+			Wrappers:  true,
+			ConstOnly: true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "wrapper/base",
+			FuncName: "Logf",
+			ArgPos:   1,
+			Wrappers: true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+	), "wrapper")
+}
+
+func TestAnalyzer_wrappers_unreachable_from_dependency(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "fmt",
+			FuncName: "Println",
+			ArgPos:   0,
+			Wrappers: true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "unreachable/types",
+					TypeName: "MyInt",
+				},
+			},
+		},
+	), "unreachable")
+}
+
+func TestAnalyzer_wrappers_not_supported(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	treporter := NewAnalysisErrorReporter(1)
+	analysistest.Run(treporter, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:       "wrapper/base",
+			FuncName:      "Info",
+			ArgPos:        1,
+			Wrappers:      true,
+			Relation:      notany.RelationIdentical,
+			RelatedArgPos: 0,
+		},
+	), "wrapper/base")
+	errs := treporter.Errors()
+	want := notany.ErrWrappersNotSupported{
+		PkgPath:  "wrapper/base",
+		FuncName: "Info",
+		ArgPos:   1,
+	}
+	if len(errs) != 1 {
+		t.Fatalf("err expected but not found: %v", want)
+	}
+	if !errors.Is(errs[0], want) {
+		t.Errorf("got %v, want %v", errs[0], want)
+	}
+}
+
+func TestAnalyzer_conflicting_fields(t *testing.T) {
+	t.Parallel()
+	allowed := []notany.Allowed{{PkgPath: "", TypeName: "int"}}
+	keys := map[string][]notany.Allowed{`"timeout"`: allowed}
+	pattern := []notany.Slot{{Name: "value", Allowed: allowed}}
+	tests := map[string]struct {
+		target notany.Target
+		want   notany.ErrConflictingFields
+	}{
+		"relation and allowed": {
+			target: notany.Target{PkgPath: "wrapper/base", FuncName: "Info", ArgPos: 1, Relation: notany.RelationIdentical, RelatedArgPos: 0, Allowed: allowed},
+			want:   notany.ErrConflictingFields{PkgPath: "wrapper/base", FuncName: "Info", ArgPos: 1, First: "Relation", Second: "Allowed"},
+		},
+		"relation and predicates": {
+			target: notany.Target{PkgPath: "wrapper/base", FuncName: "Info", ArgPos: 1, Relation: notany.RelationIdentical, RelatedArgPos: 0, Predicates: []notany.Predicate{notany.PredicatePointerFree}},
+			want:   notany.ErrConflictingFields{PkgPath: "wrapper/base", FuncName: "Info", ArgPos: 1, First: "Relation", Second: "Predicates"},
+		},
+		"relation and max size": {
+			target: notany.Target{PkgPath: "wrapper/base", FuncName: "Info", ArgPos: 1, Relation: notany.RelationIdentical, RelatedArgPos: 0, MaxSize: 8},
+			want:   notany.ErrConflictingFields{PkgPath: "wrapper/base", FuncName: "Info", ArgPos: 1, First: "Relation", Second: "MaxSize"},
+		},
+		"keys and allowed": {
+			target: notany.Target{PkgPath: "wrapper/base", FuncName: "Info", ArgPos: 1, Keys: keys, KeyArgPos: 0, Allowed: allowed},
+			want:   notany.ErrConflictingFields{PkgPath: "wrapper/base", FuncName: "Info", ArgPos: 1, First: "Keys", Second: "Allowed"},
+		},
+		"key directives and relation": {
+			target: notany.Target{PkgPath: "wrapper/base", FuncName: "Info", ArgPos: 1, KeyDirectives: true, KeyArgPos: 0, Relation: notany.RelationIdentical, RelatedArgPos: 0},
+			want:   notany.ErrConflictingFields{PkgPath: "wrapper/base", FuncName: "Info", ArgPos: 1, First: "Relation", Second: "Keys"},
+		},
+		"pattern and allowed": {
+			target: notany.Target{PkgPath: "wrapper/base", FuncName: "Logf", ArgPos: 1, Pattern: pattern, Allowed: allowed},
+			want:   notany.ErrConflictingFields{PkgPath: "wrapper/base", FuncName: "Logf", ArgPos: 1, First: "Pattern", Second: "Allowed"},
+		},
+		"keys and pattern": {
+			target: notany.Target{PkgPath: "wrapper/base", FuncName: "Logf", ArgPos: 1, Keys: keys, KeyArgPos: 0, Pattern: pattern},
+			want:   notany.ErrConflictingFields{PkgPath: "wrapper/base", FuncName: "Logf", ArgPos: 1, First: "Keys", Second: "Pattern"},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+			treporter := NewAnalysisErrorReporter(1)
+			analysistest.Run(treporter, testdata, notany.NewAnalyzer(tt.target), "wrapper/base")
+			errs := treporter.Errors()
+			if len(errs) != 1 {
+				t.Fatalf("err expected but not found: %v", tt.want)
+			}
+			if !errors.Is(errs[0], tt.want) {
+				t.Errorf("got %v, want %v", errs[0], tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyzer_interface(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:   "iface",
+			FuncName:  "Reject",
+			ArgPos:    0,
+			Interface: notany.InterfaceReject,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:   "iface",
+			FuncName:  "Accept",
+			ArgPos:    0,
+			Interface: notany.InterfaceAccept,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:   "iface",
+			FuncName:  "Narrowed",
+			ArgPos:    0,
+			Interface: notany.InterfaceNarrowed,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:   "iface",
+			FuncName:  "Assume",
+			ArgPos:    0,
+			Interface: notany.InterfaceAssume,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+	), "iface")
+}
+
+func TestAnalyzer_untyped(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "untyped",
+			FuncName: "AllowNil",
+			ArgPos:   0,
+			AllowNil: true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "float64",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "untyped",
+			FuncName: "NilEntry",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "nil",
+				},
+				{
+					PkgPath:  "",
+					TypeName: "float64",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "untyped",
+			FuncName: "Default",
+			ArgPos:   0,
+			Untyped:  notany.UntypedDefault,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "float64",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "untyped",
+			FuncName: "Representable",
+			ArgPos:   0,
+			Untyped:  notany.UntypedRepresentable,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "float64",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "untyped",
+			FuncName: "Int8",
+			ArgPos:   0,
+			Untyped:  notany.UntypedRepresentable,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int8",
+				},
+			},
+		},
+	), "untyped")
+}
+
+func TestAnalyzer_values(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "values",
+			FuncName: "SetOption",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+					Values: &notany.Values{
+						Consts: []string{`"debug"`, `"verbose"`},
+					},
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "values",
+			FuncName: "SetName",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+					Values: &notany.Values{
+						GroupPkgPath: "values/option",
+						GroupPrefix:  "Name",
+					},
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "values",
+			FuncName: "SetLevel",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "values/option",
+					TypeName: "Level",
+					Values: &notany.Values{
+						GroupPkgPath: "values/option",
+					},
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "values",
+			FuncName: "SetPort",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+					Values: &notany.Values{
+						Min:      "1",
+						Max:      "65535",
+						NonConst: notany.NonConstAccept,
+					},
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "values",
+			FuncName: "SetOptions",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+					Values: &notany.Values{
+						Consts: []string{`"debug"`, `"verbose"`},
+					},
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "values",
+			FuncName: "SetOptionList",
+			ArgPos:   0,
+			Elem:     true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+					Values: &notany.Values{
+						Consts: []string{`"debug"`, `"verbose"`},
+					},
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "values",
+			FuncName: "SetPorts",
+			ArgPos:   0,
+			Elem:     true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+					Values: &notany.Values{
+						Min:      "1",
+						Max:      "65535",
+						NonConst: notany.NonConstAccept,
+					},
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "values",
+			FuncName: "SetInt8",
+			ArgPos:   0,
+			Untyped:  notany.UntypedRepresentable,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int8",
+					Values: &notany.Values{
+						Consts: []string{"1", "2"},
+					},
+				},
+			},
+		},
+	), "values")
+}
+
+func TestAnalyzer_invalid_const(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	treporter := NewAnalysisErrorReporter(1)
+	analysistest.Run(treporter, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "oor",
+			FuncName: "OutOfRange",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+					Values: &notany.Values{
+						Min: `"a"`,
+					},
+				},
+			},
+		}), "oor")
+	errs := treporter.Errors()
+	want := notany.ErrInvalidConst{
+		Expr: `"a"`,
+	}
+	if len(errs) != 1 {
+		t.Fatalf("err expected but not found: %v", want)
+	}
+	if !errors.Is(errs[0], want) {
+		t.Errorf("got %v, want %v", errs[0], want)
+	}
+}
+
+func TestAnalyzer_const_only(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:   "constonly",
+			FuncName:  "Log",
+			ArgPos:    0,
+			ConstOnly: true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:   "constonly",
+			FuncName:  "Logs",
+			ArgPos:    0,
+			ConstOnly: true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:   "constonly",
+			FuncName:  "Any",
+			ArgPos:    0,
+			ConstOnly: true,
+		},
+	), "constonly")
+}
+
+func TestAnalyzer_relation(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:       "relation",
+			FuncName:      "Equal",
+			ArgPos:        1,
+			Relation:      notany.RelationIdentical,
+			RelatedArgPos: 2,
+		},
+		notany.Target{
+			PkgPath:       "relation",
+			FuncName:      "Assignable",
+			ArgPos:        1,
+			Relation:      notany.RelationAssignable,
+			RelatedArgPos: 0,
+		},
+	), "relation")
+}
+
+func TestAnalyzer_keys(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "keys/config",
+			FuncName: "SetValue",
+			ArgPos:   1,
+			Keys: map[string][]notany.Allowed{
+				`"name"`: {
+					{
+						PkgPath:  "",
+						TypeName: "string",
+					},
+				},
+			},
+			KeyDirectives: true,
+			KeyArgPos:     0,
+		},
+		notany.Target{
+			PkgPath:  "keys/config",
+			FuncName: "SetPointerFree",
+			ArgPos:   1,
+			Keys: map[string][]notany.Allowed{
+				`"count"`: {
+					{
+						PkgPath:  "",
+						TypeName: "int",
+					},
+				},
+			},
+			KeyArgPos:  0,
+			Predicates: []notany.Predicate{notany.PredicatePointerFree},
+		},
+		notany.Target{
+			PkgPath:  "keys/config",
+			FuncName: "SetLevel",
+			ArgPos:   1,
+			Keys: map[string][]notany.Allowed{
+				`"level"`: {
+					{
+						PkgPath:  "",
+						TypeName: "int",
+						Values: &notany.Values{
+							Consts: []string{"1", "2"},
+						},
+					},
+				},
+			},
+			KeyArgPos: 0,
+		},
+	), "keys")
+}
+
+func TestAnalyzer_pattern(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	value := []notany.Allowed{
+		{
+			PkgPath:  "",
+			TypeName: "int",
+		},
+		{
+			PkgPath:  "",
+			TypeName: "string",
+		},
+		{
+			PkgPath:  "time",
+			TypeName: "Duration",
+		},
+	}
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "log/slog",
+			FuncName: "Info",
+			ArgPos:   1,
+			Pattern: []notany.Slot{
+				{
+					Name: "key",
+					Allowed: []notany.Allowed{
+						{
+							PkgPath:  "",
+							TypeName: "string",
+						},
+					},
+				},
+				{
+					Name:    "value",
+					Allowed: value,
+				},
+			},
+			Whole: []notany.Allowed{
+				{
+					PkgPath:  "log/slog",
+					TypeName: "Attr",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "pattern",
+			FuncName: "Pairs",
+			ArgPos:   0,
+			Untraced: notany.UntracedAnnotated,
+			Pattern: []notany.Slot{
+				{
+					Allowed: []notany.Allowed{
+						{
+							PkgPath:  "",
+							TypeName: "int",
+						},
+					},
+				},
+				{
+					Allowed: []notany.Allowed{
+						{
+							PkgPath:  "",
+							TypeName: "string",
+						},
+					},
+				},
+			},
+		},
+	), "pattern")
+}
+
+func TestAnalyzer_pattern_not_variadic(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	treporter := NewAnalysisErrorReporter(1)
+	analysistest.Run(treporter, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "log/slog",
+			FuncName: "Info",
+			ArgPos:   0,
+			Pattern: []notany.Slot{
+				{
+					Allowed: []notany.Allowed{
+						{
+							PkgPath:  "",
+							TypeName: "string",
+						},
+					},
+				},
+			},
+		},
+	), "pattern")
+	errs := treporter.Errors()
+	want := notany.ErrParamKindMismatch{
+		PkgPath:  "log/slog",
+		FuncName: "Info",
+		ArgPos:   0,
+		Param:    notany.ParamAuto,
+	}
+	if len(errs) != 1 {
+		t.Fatalf("err expected but not found: %v", want)
+	}
+	if !errors.Is(errs[0], want) {
+		t.Errorf("got %v, want %v", errs[0], want)
+	}
+}
+
+func TestAnalyzer_predicate(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:    "encoding/json",
+			FuncName:   "Marshal",
+			ArgPos:     0,
+			Predicates: []notany.Predicate{notany.PredicateMarshalable},
+		},
+		notany.Target{
+			PkgPath:    "predicate",
+			FuncName:   "Set",
+			ArgPos:     1,
+			Predicates: []notany.Predicate{notany.PredicateNoLocks, notany.PredicateNoFuncChan},
+		},
+		notany.Target{
+			PkgPath:    "predicate",
+			FuncName:   "Copy",
+			ArgPos:     0,
+			Predicates: []notany.Predicate{notany.PredicatePointerFree},
+		},
+		notany.Target{
+			PkgPath:    "predicate",
+			FuncName:   "CopyInt",
+			ArgPos:     0,
+			Predicates: []notany.Predicate{notany.PredicatePointerFree},
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+			},
+		},
+	), "predicate")
+}
+
+func TestAnalyzer_size(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "size",
+			FuncName: "Record",
+			ArgPos:   1,
+			MaxSize:  64,
+		},
+		notany.Target{
+			PkgPath:    "size",
+			FuncName:   "Tag",
+			ArgPos:     0,
+			Predicates: []notany.Predicate{notany.PredicatePointerShaped},
+		},
+		notany.Target{
+			PkgPath:   "size",
+			FuncName:  "RecordNarrowed",
+			ArgPos:    0,
+			MaxSize:   64,
+			Interface: notany.InterfaceNarrowed,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "size",
+					TypeName: "Small",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:    "size",
+			FuncName:   "TagNil",
+			ArgPos:     0,
+			AllowNil:   true,
+			Interface:  notany.InterfaceNarrowed,
+			Predicates: []notany.Predicate{notany.PredicatePointerShaped},
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "size",
+					TypeName: "*Large",
+				},
+			},
+		},
+	), "size")
+}
+
+func TestAnalyzer_visibility(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:    "context",
+			FuncName:   "WithValue",
+			ArgPos:     1,
+			Predicates: []notany.Predicate{notany.PredicateCallerPackage, notany.PredicateUnexported},
+		},
+		notany.Target{
+			PkgPath:    "visibility",
+			FuncName:   "Send",
+			ArgPos:     0,
+			Predicates: []notany.Predicate{notany.PredicateCallerModule},
+		},
+		notany.Target{
+			PkgPath:    "visibility",
+			FuncName:   "Publish",
+			ArgPos:     0,
+			Predicates: []notany.Predicate{notany.PredicateExported},
+		},
+	), "visibility")
+}
+
+func TestAnalyzer_decode(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(notany.DecodeTargets()...), "decode")
+}
+
+func TestAnalyzer_consistency(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:    "sync/atomic",
+			FuncName:   "Value.Store",
+			ArgPos:     0,
+			Consistent: true,
+		},
+		notany.Target{
+			PkgPath:    "sync/atomic",
+			FuncName:   "Value.Swap",
+			ArgPos:     0,
+			Consistent: true,
+		},
+	), "consistency")
+}
+
+func TestAnalyzer_sensitive(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzerWithOptions(
+		notany.Options{
+			Sensitive: true,
+		},
+		notany.Target{
+			PkgPath:  "sensitive",
+			FuncName: "Trace",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "any",
+				},
+			},
+			Flow: true,
+		},
+		notany.Target{
+			PkgPath:  "sensitive",
+			FuncName: "Log",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "any",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "fmt",
+			FuncName: "Println",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "any",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "sensitive",
+			FuncName: "Pairs",
+			ArgPos:   0,
+			Pattern: []notany.Slot{
+				{
+					Name: "key",
+					Allowed: []notany.Allowed{
+						{
+							PkgPath:  "",
+							TypeName: "string",
+						},
+					},
+				},
+				{
+					Name: "value",
+					Allowed: []notany.Allowed{
+						{
+							PkgPath:  "",
+							TypeName: "any",
+						},
+					},
+				},
+			},
+		},
+		notany.Target{
+			PkgPath: "sensitive",
+			VarName: "Events",
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "any",
+				},
+			},
+		},
+	), "sensitive/...")
+}
+
+func TestAnalyzer_allowed_for(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:    "allowedfor/bus",
+			FuncName:   "Bus.Publish",
+			ArgPos:     1,
+			AllowedFor: true,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:    "allowedfor/bus",
+			FuncName:   "Emit",
+			ArgPos:     0,
+			AllowedFor: true,
+			Wrappers:   true,
+		},
+		notany.Target{
+			PkgPath:    "allowedfor/bus",
+			FuncName:   "Emit",
+			ArgPos:     1,
+			AllowNil:   true,
+			AllowedFor: true,
+		},
+		notany.Target{
+			PkgPath:    "allowedfor/yaml.v3",
+			FuncName:   "Marshal",
+			ArgPos:     0,
+			AllowedFor: true,
+		},
+	), "allowedfor")
+}
+
+func TestAnalyzer_methods(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "methods",
+			FuncName: "Log",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					Methods: []notany.Method{
+						{
+							Name:      "LogValue",
+							Signature: "func() slog.Value",
+						},
+					},
+				},
+				{
+					Methods: []notany.Method{
+						{
+							Name:      "MarshalText",
+							Signature: "func() ([]byte, error)",
+						},
+					},
+				},
+				{
+					Methods: []notany.Method{
+						{
+							Name:      "key",
+							Signature: "func() string",
+						},
+					},
+				},
+			},
+		},
+	), "methods")
+}
+
+func TestAnalyzer_methods_not_found(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	treporter := NewAnalysisErrorReporter(1)
+	analysistest.Run(treporter, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "methods",
+			FuncName: "Log",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					Methods: []notany.Method{
+						{
+							Name:      "Unknown",
+							Signature: "func() unknown.Type",
+						},
+					},
+				},
+			},
+		}), "methods")
+	errs := treporter.Errors()
+	want := notany.ErrIdentNotFound{
+		FromPkgPath: "methods",
+		PkgPath:     "unknown",
+		Name:        "Type",
+	}
+	if len(errs) != 1 {
+		t.Fatalf("err expected but not found: %v", want)
+	}
+	if !errors.Is(errs[0], want) {
+		t.Errorf("got %v, want %v", errs[0], want)
+	}
+}
+
+func TestAnalyzer_ambiguous_pkg_name(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	treporter := NewAnalysisErrorReporter(1)
+	analysistest.Run(treporter, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "ambiguous",
+			FuncName: "Log",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					Methods: []notany.Method{
+						{
+							Name:      "Rand",
+							Signature: "func() *rand.Rand",
+						},
+					},
+				},
+			},
+		}), "ambiguous")
+	errs := treporter.Errors()
+	want := notany.ErrAmbiguousPkgName{
+		FromPkgPath: "ambiguous",
+		PkgName:     "rand",
+		Name:        "Rand",
+	}
+	if len(errs) != 1 {
+		t.Fatalf("err expected but not found: %v", want)
+	}
+	if !errors.Is(errs[0], want) {
+		t.Errorf("got %v, want %v", errs[0], want)
+	}
+}
+
+func TestAnalyzer_invalid_type_expr(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	treporter := NewAnalysisErrorReporter(1)
+	analysistest.Run(treporter, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "methods",
+			FuncName: "Log",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					Methods: []notany.Method{
+						{
+							Name:      "LogValue",
+							Signature: "func() slog.Value {",
+						},
+					},
+				},
+			},
+		}), "methods")
+	errs := treporter.Errors()
+	want := notany.ErrInvalidTypeExpr{
+		Expr: "func() slog.Value {",
+	}
+	if len(errs) != 1 {
+		t.Fatalf("err expected but not found: %v", want)
+	}
+	if !errors.Is(errs[0], want) {
+		t.Errorf("got %v, want %v", errs[0], want)
+	}
+}
+
+func TestAnalyzer_type_expr(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	allowed := func(pkgPath string, typeNames ...string) []notany.Allowed {
+		ret := make([]notany.Allowed, 0, len(typeNames))
+		for _, name := range typeNames {
+			ret = append(ret, notany.Allowed{
+				PkgPath:  pkgPath,
+				TypeName: name,
+			})
+		}
+		return ret
+	}
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "typeexpr",
+			FuncName: "Do",
+			ArgPos:   0,
+			Allowed: append(
+				allowed("", "*int", "[]byte", "map[string]string", "[]*pkg.Item", "func() error", "chan<- pkg.Event"),
+				allowed("typeexpr/pkg", "Option[int]", "Pair[string, *]")...,
+			),
+		},
+		notany.Target{
+			PkgPath:  "typeexpr",
+			FuncName: "Any",
+			ArgPos:   0,
+			Allowed:  allowed("typeexpr/pkg", "Option[*]"),
+		},
+	), "typeexpr")
+}
+
+func TestAnalyzer_invalid_type_name(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	for _, typeName := range []string{"[]int{", "Option[int, int]", "[]Option[*]"} {
+		typeName := typeName
+		t.Run(typeName, func(t *testing.T) {
+			t.Parallel()
+			treporter := NewAnalysisErrorReporter(1)
+			analysistest.Run(treporter, testdata, notany.NewAnalyzer(
+				notany.Target{
+					PkgPath:  "typeexpr",
+					FuncName: "Do",
+					ArgPos:   0,
+					Allowed: []notany.Allowed{
+						{
+							PkgPath:  "typeexpr/pkg",
+							TypeName: typeName,
+						},
+					},
+				}), "typeexpr")
+			errs := treporter.Errors()
+			want := notany.ErrInvalidTypeExpr{
+				Expr: typeName,
+			}
+			if len(errs) != 1 {
+				t.Fatalf("err expected but not found: %v", want)
+			}
+			if !errors.Is(errs[0], want) {
+				t.Errorf("got %v, want %v", errs[0], want)
+			}
+		})
+	}
+}
+
+func TestAnalyzer_wildcard(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "wildcard",
+			FuncName: "Publish",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "wildcard/gen/events",
+					TypeName: "*",
+				},
+				{
+					// unreachable packages are not resolved.
+					PkgPath:  "example.com/notfound",
+					TypeName: "*",
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "wildcard",
+			FuncName: "PublishPtr",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "wildcard/gen/events/...",
+					TypeName: "*.*",
+				},
+			},
+		},
+	), "wildcard")
+}
+
+func TestAnalyzer_wildcard_without_pkg_path(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	treporter := NewAnalysisErrorReporter(1)
+	analysistest.Run(treporter, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "wildcard",
+			FuncName: "Publish",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "*",
+				},
+			},
+		}), "wildcard")
+	errs := treporter.Errors()
+	want := notany.ErrWildcardWithoutPkgPath{
+		PkgPath:  "",
+		TypeName: "*",
+	}
+	if len(errs) != 1 {
+		t.Fatalf("err expected but not found: %v", want)
+	}
+	if !errors.Is(errs[0], want) {
+		t.Errorf("got %v, want %v", errs[0], want)
+	}
+}
+
+func TestAnalyzer_match(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	analysistest.Run(t, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "match",
+			FuncName: "Underlying",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+					Match:    notany.MatchUnderlying,
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "match",
+			FuncName: "Assignable",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "io",
+					TypeName: "Reader",
+					Match:    notany.MatchAssignable,
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "match",
+			FuncName: "Convertible",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "float64",
+					Match:    notany.MatchConvertible,
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "match",
+			FuncName: "Exact",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "error",
+					Match:    notany.MatchExactInterface,
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "match",
+			FuncName: "Values",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+					Match:    notany.MatchUnderlying,
+					Values: &notany.Values{
+						Consts: []string{`"debug"`, `"info"`},
+					},
+				},
+			},
+		},
+		notany.Target{
+			PkgPath:  "match",
+			FuncName: "AnyOf",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "string",
+					Match:    notany.MatchUnderlying,
+					Values: &notany.Values{
+						Consts: []string{`"debug"`, `"info"`},
+					},
+				},
+				{
+					PkgPath:  "match",
+					TypeName: "MyString",
+				},
+			},
+		},
+	), "match")
+}
+
+func TestAnalyzer_match_exact_not_interface(t *testing.T) {
+	t.Parallel()
+	testdata := testutil.WithModules(t, analysistest.TestData(), nil)
+	treporter := NewAnalysisErrorReporter(1)
+	analysistest.Run(treporter, testdata, notany.NewAnalyzer(
+		notany.Target{
+			PkgPath:  "match",
+			FuncName: "Exact",
+			ArgPos:   0,
+			Allowed: []notany.Allowed{
+				{
+					PkgPath:  "",
+					TypeName: "int",
+					Match:    notany.MatchExactInterface,
+				},
+			},
+		}), "match")
+	errs := treporter.Errors()
+	want := notany.ErrNotInterfaceType{
+		PkgPath:  "",
+		TypeName: "int",
+	}
+	if len(errs) != 1 {
+		t.Fatalf("err expected but not found: %v", want)
+	}
+	if !errors.Is(errs[0], want) {
+		t.Errorf("got %v, want %v", errs[0], want)
+	}
+}
+
 var _ analysistest.Testing = (*analysisErrorReporter)(nil)
 
 type analysisErrorReporter struct {
@@ -0,0 +1,133 @@
+package notany
+
+import (
+	"fmt"
+	"go/ast"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// Slot is a slot of a repeating pattern over variadic arguments.
+type Slot struct {
+	// Name of the slot such as key or value, which is used in diagnostics.
+	// If it is empty, the slot is named by its index.
+	Name string
+	// List of allowed types for the arguments at the slot.
+	Allowed []Allowed
+}
+
+type patternSlot struct {
+	Name    string
+	Allowed *allowedTypes
+	Values  []*valueConstraint
+	// AllowedList is the list from which Allowed is built.
+	AllowedList []Allowed
+}
+
+// toPatternSlots returns the slots of the pattern with the allowed types.
+func toPatternSlots(pass *analysis.Pass, pattern []Slot) ([]*patternSlot, error) {
+	ret := make([]*patternSlot, 0, len(pattern))
+	for i, s := range pattern {
+		allowed, err := toAllowedTypes(pass, s.Allowed)
+		if err != nil {
+			return nil, err
+		}
+		values, err := toValueConstraints(pass, s.Allowed)
+		if err != nil {
+			return nil, err
+		}
+		name := s.Name
+		if name == "" {
+			name = fmt.Sprintf("slot %d", i)
+		}
+		ret = append(ret, &patternSlot{
+			Name:        name,
+			Allowed:     allowed,
+			Values:      values,
+			AllowedList: s.Allowed,
+		})
+	}
+	return ret, nil
+}
+
+// patternNotAllowed returns the result if the variadic arguments of the call n from ArgPos do not follow the pattern of t.
+// The elements of a spread composite literal ([]any{...}...) are checked as arguments,
+// and other spread arguments are handled by the Untraced policy of t.
+func patternNotAllowed(pass *analysis.Pass, t *analysisTarget, n *ast.CallExpr, args []callArg) *notAllowed {
+	var slotArgs []slotArg
+	for p := t.ArgPos; p < len(args); p++ {
+		arg := args[p]
+		if n.Ellipsis.IsValid() && p == len(args)-1 {
+			elems, ok := spreadElems(pass, arg.Expr, p)
+			if !ok {
+				ret := untracedNotAllowed(pass, t, n, arg.Expr, elemTypeOf(arg.Type))
+				if ret != nil {
+					ret.ArgPos = p
+				}
+				return ret
+			}
+			slotArgs = append(slotArgs, elems...)
+			continue
+		}
+		slotArgs = append(slotArgs, slotArg{callArg: arg, ArgPos: p})
+	}
+
+	whole := &analysisTarget{Allowed: t.Whole}
+	slot := 0
+	for i, arg := range slotArgs {
+		if slot == 0 && whole.Allow(arg.Type) && !isNil(pass, arg.Expr) {
+			// the argument occupies a whole repetition by itself.
+			continue
+		}
+		s := t.Pattern[slot]
+		st := *t
+		st.Pattern = nil
+		st.Allowed = s.Allowed
+		st.Values = s.Values
+		st.AllowedList = s.AllowedList
+		st.AllowNil = t.AllowNil || allowsNil(s.AllowedList)
+		if ret := checkArg(pass, &st, n, arg.callArg, arg.ArgPos); ret != nil {
+			ret.Slot = s.Name
+			return ret
+		}
+		slot = (slot + 1) % len(t.Pattern)
+		if slot != 0 && i == len(slotArgs)-1 {
+			// the last repetition is incomplete.
+			return &notAllowed{
+				ArgExpr:  arg.Expr,
+				ArgType:  arg.Type,
+				ArgPos:   arg.ArgPos,
+				Slot:     s.Name,
+				Dangling: true,
+			}
+		}
+	}
+	return nil
+}
+
+// slotArg is an argument filling a slot of a pattern.
+type slotArg struct {
+	callArg
+	// ArgPos is the position of the argument, or of the spread argument containing it.
+	ArgPos int
+}
+
+// spreadElems returns the elements of the spread argument expr at argPos if it is a composite literal.
+// ok is false if the elements cannot be listed in order.
+func spreadElems(pass *analysis.Pass, expr ast.Expr, argPos int) (elems []slotArg, ok bool) {
+	lit, ok := astutil.Unparen(expr).(*ast.CompositeLit)
+	if !ok {
+		return nil, false
+	}
+	for _, elt := range lit.Elts {
+		if _, ok := elt.(*ast.KeyValueExpr); ok {
+			return nil, false
+		}
+		elems = append(elems, slotArg{
+			callArg: callArg{Expr: elt, Type: pass.TypesInfo.TypeOf(elt)},
+			ArgPos:  argPos,
+		})
+	}
+	return elems, true
+}
@@ -0,0 +1,334 @@
+package notany
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// Predicate is a structural property of a type which is checked recursively over
+// struct fields, array and slice elements, and map keys and values.
+type Predicate int
+
+const (
+	// PredicateMarshalable requires the type to be JSON-marshalable.
+	// Chans, funcs, complex numbers and unsafe pointers are not marshalable, and map keys must be strings or integers.
+	// Only exported and embedded fields are checked, and types implementing json.Marshaler or encoding.TextMarshaler are marshalable.
+	PredicateMarshalable Predicate = iota
+	// PredicateNoLocks requires the type to contain no locks such as sync.Mutex.
+	// The values pointed to are not checked because copying a pointer does not copy the lock.
+	PredicateNoLocks
+	// PredicateNoFuncChan requires the type to contain no funcs or chans including the values pointed to.
+	PredicateNoFuncChan
+	// PredicatePointerFree requires the type to contain no pointers, slices, maps, chans, funcs, interfaces or unsafe pointers.
+	// Strings are pointer-free because they are immutable.
+	PredicatePointerFree
+	// PredicatePointerShaped requires the type to be stored directly in an interface without a heap allocation when boxed,
+	// such as pointers, maps, chans, funcs, and structs or arrays consisting of a single pointer-shaped element.
+	// Zero-sized types are also accepted. It is not checked recursively.
+	PredicatePointerShaped
+	// The following predicates are evaluated against the named type of the argument, that is, the type itself or the type pointed to by it,
+	// relative to the calling package. They are not checked recursively, and types without names violate them.
+
+	// PredicateCallerPackage requires the type to be defined in the calling package.
+	PredicateCallerPackage
+	// PredicateCallerModule requires the type to be defined in the module of the calling package.
+	PredicateCallerModule
+	// PredicateUnexported requires the type to be unexported.
+	PredicateUnexported
+	// PredicateExported requires the type to be exported or predeclared.
+	PredicateExported
+	// The following predicates are evaluated against the argument itself, which must be a pointer.
+	// They are not checked recursively.
+
+	// PredicateNonNilPointer requires the argument to be a non-nil pointer to a value which can be decoded into, as decoders such as json.Unmarshal do.
+	// Pointers to funcs, chans and unsafe pointers violate it, and so do nil pointers converted like (*T)(nil)
+	// and local pointer variables which are declared without values and never assigned.
+	// nil is rejected unless AllowNil is true, and arguments of interface types are accepted because their dynamic types are unknown.
+	PredicateNonNilPointer
+	// PredicateErrorTarget requires the argument to be a non-nil pointer to an interface type or to a type implementing error,
+	// as the target of errors.As does.
+	PredicateErrorTarget
+)
+
+func (p Predicate) String() string {
+	switch p {
+	case PredicateMarshalable:
+		return "marshalable"
+	case PredicateNoLocks:
+		return "no-locks"
+	case PredicateNoFuncChan:
+		return "no-func-chan"
+	case PredicatePointerFree:
+		return "pointer-free"
+	case PredicatePointerShaped:
+		return "pointer-shaped"
+	case PredicateCallerPackage:
+		return "caller-package"
+	case PredicateCallerModule:
+		return "caller-module"
+	case PredicateUnexported:
+		return "unexported"
+	case PredicateExported:
+		return "exported"
+	case PredicateNonNilPointer:
+		return "non-nil-pointer"
+	case PredicateErrorTarget:
+		return "error-target"
+	}
+	return fmt.Sprintf("Predicate(%d)", int(p))
+}
+
+// violation is a part of a type which does not satisfy a predicate.
+type violation struct {
+	Predicate Predicate
+	// Path is the path from the type to the part such as .Field, [] for elements, or [key] for map keys.
+	// It is empty if the type itself violates the predicate.
+	Path string
+	Type types.Type
+	// Size is the size of Type in bytes if the violation is about the size.
+	Size int64
+	// MaxSize is the size limit which Size exceeds.
+	// It is 0 if the violation is about a predicate.
+	MaxSize int64
+	// Sensitive is true if Type is annotated with //notany:sensitive.
+	Sensitive bool
+	// Nil is true if the value of Type is a nil pointer.
+	Nil bool
+}
+
+func (v *violation) String() string {
+	switch {
+	case v.Sensitive && v.Path == "":
+		return fmt.Sprintf("%s is sensitive", v.Type)
+	case v.Sensitive:
+		return fmt.Sprintf("%s (%s) is sensitive", v.Path, v.Type)
+	case v.Nil:
+		return fmt.Sprintf("nil %s violates %s", v.Type, v.Predicate)
+	case v.MaxSize > 0:
+		return fmt.Sprintf("%s has %d bytes exceeding the max size of %d bytes", v.Type, v.Size, v.MaxSize)
+	case v.Predicate == PredicatePointerShaped:
+		return fmt.Sprintf("%s (%d bytes) violates %s", v.Type, v.Size, v.Predicate)
+	case v.Path == "":
+		return fmt.Sprintf("%s violates %s", v.Type, v.Predicate)
+	}
+	return fmt.Sprintf("%s (%s) violates %s", v.Path, v.Type, v.Predicate)
+}
+
+type predicateChecker struct {
+	pred Predicate
+	// seen holds the named types already visited for cycle detection.
+	seen map[types.Type]bool
+}
+
+func (c *predicateChecker) check(typ types.Type, path string) *violation {
+	typ = types.Unalias(typ)
+	if named, ok := typ.(*types.Named); ok {
+		if c.seen[named] {
+			return nil
+		}
+		c.seen[named] = true
+		if c.pred == PredicateMarshalable && hasMarshaler(named) {
+			return nil
+		}
+	}
+	bad := &violation{Predicate: c.pred, Path: path, Type: typ}
+	switch u := typ.Underlying().(type) {
+	case *types.Basic:
+		switch {
+		case u.Kind() == types.UnsafePointer:
+			if c.pred == PredicateMarshalable || c.pred == PredicatePointerFree {
+				return bad
+			}
+		case u.Info()&types.IsComplex != 0:
+			if c.pred == PredicateMarshalable {
+				return bad
+			}
+		}
+		return nil
+	case *types.Chan, *types.Signature:
+		if c.pred == PredicateNoLocks {
+			return nil
+		}
+		return bad
+	case *types.Interface:
+		// the dynamic types are unknown.
+		if c.pred == PredicatePointerFree {
+			return bad
+		}
+		return nil
+	case *types.Pointer:
+		switch c.pred {
+		case PredicatePointerFree:
+			return bad
+		case PredicateNoLocks:
+			return nil
+		}
+		return c.check(u.Elem(), path)
+	case *types.Slice:
+		if c.pred == PredicatePointerFree {
+			return bad
+		}
+		if c.pred == PredicateNoLocks {
+			return nil
+		}
+		return c.check(u.Elem(), path+"[]")
+	case *types.Array:
+		return c.check(u.Elem(), path+"[]")
+	case *types.Map:
+		switch c.pred {
+		case PredicatePointerFree:
+			return bad
+		case PredicateNoLocks:
+			return nil
+		case PredicateMarshalable:
+			if !marshalableKey(u.Key()) {
+				return &violation{Predicate: c.pred, Path: path + "[key]", Type: u.Key()}
+			}
+		}
+		if v := c.check(u.Key(), path+"[key]"); v != nil {
+			return v
+		}
+		return c.check(u.Elem(), path+"[]")
+	case *types.Struct:
+		if c.pred == PredicateNoLocks && isLock(typ) {
+			return bad
+		}
+		for i := 0; i < u.NumFields(); i++ {
+			f := u.Field(i)
+			if c.pred == PredicateMarshalable && !f.Exported() && !f.Embedded() {
+				continue
+			}
+			if v := c.check(f.Type(), path+"."+f.Name()); v != nil {
+				return v
+			}
+		}
+		return nil
+	}
+	return nil
+}
+
+// hasMarshaler reports whether typ or the pointer to it has the method MarshalJSON or MarshalText.
+func hasMarshaler(typ types.Type) bool {
+	for _, name := range []string{"MarshalJSON", "MarshalText"} {
+		if obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, name); obj != nil {
+			if _, ok := obj.(*types.Func); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// marshalableKey reports whether typ can be a key of a JSON object.
+func marshalableKey(typ types.Type) bool {
+	if b, ok := typ.Underlying().(*types.Basic); ok && b.Info()&(types.IsString|types.IsInteger) != 0 {
+		return true
+	}
+	obj, _, _ := types.LookupFieldOrMethod(typ, true, nil, "MarshalText")
+	_, ok := obj.(*types.Func)
+	return ok
+}
+
+// isLock reports whether the pointer to typ has the methods Lock and Unlock declared by typ itself but typ does not, as sync.Mutex does.
+// Methods promoted from embedded fields are not considered, so that the path to the embedded lock is reported.
+func isLock(typ types.Type) bool {
+	ptr := types.NewMethodSet(types.NewPointer(typ))
+	val := types.NewMethodSet(typ)
+	for _, name := range []string{"Lock", "Unlock"} {
+		sel := ptr.Lookup(nil, name)
+		if sel == nil || len(sel.Index()) != 1 {
+			return false
+		}
+	}
+	return val.Lookup(nil, "Lock") == nil
+}
+
+var errorType = types.Universe.Lookup("error").Type().Underlying().(*types.Interface)
+
+// isPointerTo reports whether typ is a pointer satisfying the pointer predicate p.
+func isPointerTo(p Predicate, typ types.Type) bool {
+	ptr, ok := typ.Underlying().(*types.Pointer)
+	if !ok {
+		return false
+	}
+	if p == PredicateErrorTarget {
+		return types.IsInterface(ptr.Elem()) || types.Implements(ptr.Elem(), errorType)
+	}
+	switch u := ptr.Elem().Underlying().(type) {
+	case *types.Signature, *types.Chan:
+		return false
+	case *types.Basic:
+		return u.Kind() != types.UnsafePointer
+	}
+	return true
+}
+
+// nilPointerViolation returns the violation if the pointer predicates of a require a non-nil pointer and expr is a typed nil pointer.
+func (a *analysisTarget) nilPointerViolation(pass *analysis.Pass, expr ast.Expr) *violation {
+	for _, p := range a.Predicates {
+		if p != PredicateNonNilPointer && p != PredicateErrorTarget {
+			continue
+		}
+		if isNilPointer(pass, expr) {
+			return &violation{Predicate: p, Type: pass.TypesInfo.TypeOf(expr), Nil: true}
+		}
+	}
+	return nil
+}
+
+// isNilPointer reports whether expr is a nil pointer of a pointer type,
+// that is, nil converted to a pointer type, or a local pointer variable declared without a value and never assigned.
+func isNilPointer(pass *analysis.Pass, expr ast.Expr) bool {
+	if _, ok := pass.TypesInfo.TypeOf(expr).(*types.Pointer); !ok {
+		return false
+	}
+	switch e := astutil.Unparen(expr).(type) {
+	case *ast.CallExpr:
+		if tv, ok := pass.TypesInfo.Types[e.Fun]; ok && tv.IsType() && len(e.Args) == 1 {
+			return isNil(pass, e.Args[0]) || isNilPointer(pass, e.Args[0])
+		}
+	case *ast.Ident:
+		v, ok := pass.TypesInfo.Uses[e].(*types.Var)
+		if !ok || !isLocal(v) {
+			return false
+		}
+		return neverAssigned(pass, v)
+	}
+	return false
+}
+
+// neverAssigned reports whether the local variable v is declared by a var declaration without a value,
+// and is neither assigned nor addressed afterwards.
+func neverAssigned(pass *analysis.Pass, v *types.Var) bool {
+	file := fileOf(pass, v.Pos())
+	if file == nil {
+		return false
+	}
+	path, _ := astutil.PathEnclosingInterval(file, v.Pos(), v.Pos())
+	if len(path) < 2 {
+		return false
+	}
+	if spec, ok := path[1].(*ast.ValueSpec); !ok || len(spec.Values) > 0 {
+		return false
+	}
+	assigned := false
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.AssignStmt:
+			for _, lhs := range n.Lhs {
+				assigned = assigned || isVar(pass, lhs, v)
+			}
+		case *ast.UnaryExpr:
+			assigned = assigned || (n.Op == token.AND && isVar(pass, n.X, v))
+		case *ast.RangeStmt:
+			assigned = assigned || (n.Key != nil && isVar(pass, n.Key, v)) || (n.Value != nil && isVar(pass, n.Value, v))
+		}
+		return !assigned
+	})
+	return !assigned
+}
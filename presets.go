@@ -0,0 +1,21 @@
+package notany
+
+// DecodeTargets returns the targets for the standard library functions which decode into their arguments.
+// The arguments must be non-nil pointers, and the target of errors.As must be a non-nil pointer
+// to an interface type or to a type implementing error.
+//
+//	notany.NewAnalyzer(notany.DecodeTargets()...)
+func DecodeTargets() []Target {
+	pointer := []Predicate{PredicateNonNilPointer}
+	return []Target{
+		{PkgPath: "encoding/json", FuncName: "Unmarshal", ArgPos: 1, Predicates: pointer},
+		{PkgPath: "encoding/json", FuncName: "Decoder.Decode", ArgPos: 0, Predicates: pointer},
+		{PkgPath: "encoding/xml", FuncName: "Unmarshal", ArgPos: 1, Predicates: pointer},
+		{PkgPath: "encoding/xml", FuncName: "Decoder.Decode", ArgPos: 0, Predicates: pointer},
+		{PkgPath: "encoding/xml", FuncName: "Decoder.DecodeElement", ArgPos: 0, Predicates: pointer},
+		{PkgPath: "encoding/gob", FuncName: "Decoder.Decode", ArgPos: 0, Predicates: pointer},
+		{PkgPath: "database/sql", FuncName: "Rows.Scan", ArgPos: 0, Predicates: pointer},
+		{PkgPath: "database/sql", FuncName: "Row.Scan", ArgPos: 0, Predicates: pointer},
+		{PkgPath: "errors", FuncName: "As", ArgPos: 1, Predicates: []Predicate{PredicateErrorTarget}},
+	}
+}
@@ -0,0 +1,122 @@
+package notany
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// sensitiveFact is exported for a type annotated with //notany:sensitive.
+type sensitiveFact struct{}
+
+func (*sensitiveFact) AFact() {}
+
+func (*sensitiveFact) String() string {
+	return "sensitive"
+}
+
+// exportSensitiveFacts exports facts for the types annotated with //notany:sensitive in the package.
+func exportSensitiveFacts(pass *analysis.Pass) {
+	for _, file := range pass.Files {
+		for _, decl := range file.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				ts := spec.(*ast.TypeSpec)
+				groups := []*ast.CommentGroup{ts.Doc, ts.Comment}
+				if !gd.Lparen.IsValid() {
+					groups = append(groups, gd.Doc)
+				}
+				if _, _, ok := declDirective(groups, "sensitive"); !ok {
+					continue
+				}
+				if obj, ok := pass.TypesInfo.Defs[ts.Name].(*types.TypeName); ok {
+					pass.ExportObjectFact(obj, new(sensitiveFact))
+				}
+			}
+		}
+	}
+}
+
+// sensitiveTypes returns the types annotated with //notany:sensitive in the package and its dependencies.
+func sensitiveTypes(pass *analysis.Pass) map[*types.TypeName]bool {
+	ret := make(map[*types.TypeName]bool)
+	for _, f := range pass.AllObjectFacts() {
+		if _, ok := f.Fact.(*sensitiveFact); !ok {
+			continue
+		}
+		if obj, ok := f.Object.(*types.TypeName); ok {
+			ret[obj] = true
+		}
+	}
+	return ret
+}
+
+// sensitiveOf returns the violation if typ is a sensitive type, a pointer to it, or a type containing it.
+// If nil is returned, typ is not sensitive.
+func sensitiveOf(typ types.Type, sensitive map[*types.TypeName]bool) *violation {
+	if len(sensitive) == 0 || typ == nil {
+		return nil
+	}
+	return sensitivePath(typ, "", sensitive, make(map[types.Type]bool))
+}
+
+func sensitivePath(typ types.Type, path string, sensitive map[*types.TypeName]bool, seen map[types.Type]bool) *violation {
+	typ = types.Unalias(typ)
+	if named, ok := typ.(*types.Named); ok {
+		if sensitive[named.Origin().Obj()] {
+			return &violation{Path: path, Type: typ, Sensitive: true}
+		}
+		if seen[named] {
+			return nil
+		}
+		seen[named] = true
+	}
+	switch u := typ.Underlying().(type) {
+	case *types.Pointer:
+		return sensitivePath(u.Elem(), path, sensitive, seen)
+	case *types.Slice:
+		return sensitivePath(u.Elem(), path+"[]", sensitive, seen)
+	case *types.Array:
+		return sensitivePath(u.Elem(), path+"[]", sensitive, seen)
+	case *types.Map:
+		if v := sensitivePath(u.Key(), path+"[key]", sensitive, seen); v != nil {
+			return v
+		}
+		return sensitivePath(u.Elem(), path+"[]", sensitive, seen)
+	case *types.Struct:
+		for i := 0; i < u.NumFields(); i++ {
+			f := u.Field(i)
+			if v := sensitivePath(f.Type(), path+"."+f.Name(), sensitive, seen); v != nil {
+				return v
+			}
+		}
+	}
+	return nil
+}
+
+// sensitiveNotAllowed returns the result if any of the arguments checked by t is sensitive.
+func sensitiveNotAllowed(t *analysisTarget, sig *types.Signature, args []callArg) *notAllowed {
+	if len(t.Sensitive) == 0 || t.ArgPos >= len(args) {
+		return nil
+	}
+	end := t.ArgPos + 1
+	if isVariadicParam(sig, t.ArgPos) {
+		end = len(args)
+	}
+	for p := t.ArgPos; p < end; p++ {
+		if v := sensitiveOf(args[p].Type, t.Sensitive); v != nil {
+			return &notAllowed{
+				ArgExpr:   args[p].Expr,
+				ArgType:   args[p].Type,
+				ArgPos:    p,
+				Violation: v,
+			}
+		}
+	}
+	return nil
+}
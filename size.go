@@ -0,0 +1,65 @@
+package notany
+
+import (
+	"go/types"
+)
+
+// constrained reports whether the types allowed by a are constrained by the predicates or the size limit.
+func (a *analysisTarget) constrained() bool {
+	return len(a.Predicates) > 0 || a.MaxSize > 0
+}
+
+// violationOf returns the first violation of the predicates or the size limit of a by typ.
+// If nil is returned, typ satisfies all of them.
+// The size limit and pointer-shaped are not checked for untyped types such as the type of nil, which have no sizes.
+func (a *analysisTarget) violationOf(typ types.Type) *violation {
+	if a.MaxSize > 0 && !types.IsInterface(typ) && !isUntyped(typ) {
+		if size := a.Sizes.Sizeof(typ); size > a.MaxSize {
+			return &violation{Type: typ, Size: size, MaxSize: a.MaxSize}
+		}
+	}
+	for _, p := range a.Predicates {
+		switch p {
+		case PredicatePointerShaped:
+			if !types.IsInterface(typ) && !isUntyped(typ) && !isPointerShaped(typ) && a.Sizes.Sizeof(typ) > 0 {
+				return &violation{Predicate: p, Type: typ, Size: a.Sizes.Sizeof(typ)}
+			}
+			continue
+		case PredicateNonNilPointer, PredicateErrorTarget:
+			if !types.IsInterface(typ) && !isPointerTo(p, typ) {
+				return &violation{Predicate: p, Type: typ}
+			}
+			continue
+		case PredicateCallerPackage, PredicateCallerModule, PredicateUnexported, PredicateExported:
+			if !a.visible(p, typ) {
+				return &violation{Predicate: p, Type: typ}
+			}
+			continue
+		}
+		if v := (&predicateChecker{pred: p, seen: make(map[types.Type]bool)}).check(typ, ""); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+// isUntyped reports whether typ is an untyped basic type.
+func isUntyped(typ types.Type) bool {
+	b, ok := typ.(*types.Basic)
+	return ok && b.Info()&types.IsUntyped != 0
+}
+
+// isPointerShaped reports whether a value of typ is stored directly in an interface without allocation.
+func isPointerShaped(typ types.Type) bool {
+	switch u := typ.Underlying().(type) {
+	case *types.Pointer, *types.Chan, *types.Map, *types.Signature:
+		return true
+	case *types.Basic:
+		return u.Kind() == types.UnsafePointer
+	case *types.Struct:
+		return u.NumFields() == 1 && isPointerShaped(u.Field(0).Type())
+	case *types.Array:
+		return u.Len() == 1 && isPointerShaped(u.Elem())
+	}
+	return false
+}
@@ -13,7 +13,7 @@ func f() {
 	Target(1, "2", 3.3)    // ok
 	Target(1, 1.1, "3")    // want "not allowed"
 	Target(1, nil, "3")    // want "not allowed"
-	Target(1, str("2"), 3) // ok because str is an alias of string
+	Target(1, str("2"), 3) // ok because type str = string declares an alias, so str is string itself
 
 	// builtin alias
 	Target(1, uint8(1), 3)  // ok
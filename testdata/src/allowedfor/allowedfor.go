@@ -0,0 +1,41 @@
+package allowedfor
+
+import (
+	"allowedfor/bus"
+	"allowedfor/events"
+	"allowedfor/logger"
+	"allowedfor/yaml.v3"
+)
+
+//notany:allowed-for allowedfor/bus.Emit
+type local struct{} // want local:"allowed-for\\(allowedfor/bus.Emit\\)"
+
+//notany:allowed-for allowedfor/yaml.v3.Marshal
+type doc struct{} // want doc:"allowed-for\\(allowedfor/yaml.v3.Marshal\\)"
+
+type (
+	bad1 struct{} //notany:allowed-for allowedfor/bus.Nope // want `invalid target allowedfor/bus.Nope in //notany:allowed-for: function Nope not found in allowedfor/bus`
+	bad2 struct{} //notany:allowed-for allowedfor/bus.Bus.Nope // want `invalid target allowedfor/bus.Bus.Nope in //notany:allowed-for: method Nope not found in allowedfor/bus.Bus`
+	bad3 struct{} //notany:allowed-for allowedfor/bus.Emit:x // want `invalid target allowedfor/bus.Emit:x in //notany:allowed-for: invalid arg position "x"`
+	bad4 struct{} //notany:allowed-for Emit // want `invalid target Emit in //notany:allowed-for: want pkg/path.FuncName`
+)
+
+func f(b *bus.Bus) {
+	b.Publish("user", events.UserCreated{})    // ok
+	b.Publish("order", events.OrderPlaced{})   // ok
+	b.Publish("order", events.OrderCanceled{}) // want `allowedfor/events.OrderCanceled is not allowed for the 2th arg`
+	b.Publish("order", 1)                      // ok because int is configured
+
+	bus.Emit(events.OrderPlaced{}, events.Meta{})        // ok
+	bus.Emit(events.Meta{}, events.Meta{})               // want `allowedfor/events.Meta is not allowed for the 1th arg`
+	bus.Emit(events.OrderPlaced{}, events.OrderPlaced{}) // want `allowedfor/events.OrderPlaced is not allowed for the 2th arg`
+	bus.Emit(local{}, local{})                           // ok
+	bus.Emit(events.UserCreated{}, nil)                  // want `allowedfor/events.UserCreated is not allowed for the 1th arg`
+
+	// wrapper in another package
+	logger.Emit(events.OrderPlaced{}) // ok
+	logger.Emit(events.Meta{})        // want `allowedfor/events.Meta is not allowed for the 1th arg of func allowedfor/logger.Emit`
+
+	yaml.Marshal(doc{})   // ok
+	yaml.Marshal(local{}) // want `allowedfor.local is not allowed for the 1th arg`
+}
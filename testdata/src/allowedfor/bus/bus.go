@@ -0,0 +1,7 @@
+package bus
+
+type Bus struct{}
+
+func (*Bus) Publish(topic string, event any) {}
+
+func Emit(event any, meta any) {}
@@ -0,0 +1,15 @@
+package events
+
+//notany:allowed-for allowedfor/bus.Bus.Publish
+type UserCreated struct{}
+
+type (
+	// OrderPlaced is emitted when an order is placed.
+	//notany:allowed-for allowedfor/bus.Bus.Publish:1 allowedfor/bus.Emit:0
+	OrderPlaced struct{}
+
+	// not annotated
+	OrderCanceled struct{}
+)
+
+type Meta struct{} //notany:allowed-for allowedfor/bus.Emit:1
@@ -0,0 +1,3 @@
+module allowedfor
+
+go 1.20
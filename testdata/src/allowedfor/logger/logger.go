@@ -0,0 +1,8 @@
+package logger
+
+import "allowedfor/bus"
+
+// event is forwarded to bus.Emit.
+func Emit(event any) {
+	bus.Emit(event, nil)
+}
@@ -0,0 +1,11 @@
+package ambiguous
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+)
+
+var _ = rand.Int
+var _ = randv2.Int
+
+func Log(v any) {}
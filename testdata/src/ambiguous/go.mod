@@ -0,0 +1,3 @@
+module ambiguous
+
+go 1.22
@@ -0,0 +1,35 @@
+package consistency
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+var config atomic.Value
+
+type Cache struct {
+	last  atomic.Value
+	count atomic.Value
+}
+
+func f(c *Cache, v any) {
+	config.Store(map[string]string{}) // ok
+	config.Store(map[string]string{}) // ok
+	config.Store(map[string]int{})    // want `map\[string\]int is not allowed for the 1th arg of .*Value\).Store.* because map\[string\]string is already passed for config`
+	config.Store(v)                   // ok
+
+	c.last.Store(time.Now())  // ok
+	c.count.Store(1)          // ok
+	c.last.Store(time.Second) // want `time.Duration is not allowed .* because time.Time is already passed for last`
+	c.count.Store(int64(1))   // want `int64 is not allowed .* because int is already passed for count`
+	c.count.Swap(2)           // ok
+	c.count.Swap("2")         // want `string is not allowed for the 1th arg of .*Value\).Swap.* because int is already passed for count`
+
+	var local atomic.Value
+	local.Store(1)   // ok
+	local.Store("1") // ok
+}
+
+func g(c *Cache) {
+	c.last.Store("str") // want `string is not allowed .* because time.Time is already passed for last`
+}
@@ -0,0 +1,3 @@
+module consistency
+
+go 1.20
@@ -0,0 +1,33 @@
+package constonly
+
+import "fmt"
+
+const prefix = "request: "
+
+func f(s string, n int) {
+	Log("started")            // ok
+	Log(prefix + "finished")  // ok because concatenation of constants is a constant.
+	Log(prefix + s)           // want "must be a compile-time constant"
+	Log(fmt.Sprintf("%d", n)) // want "must be a compile-time constant"
+	Log(1)                    // want "int"
+	Log(n)                    // want "int" "must be a compile-time constant"
+	Log(nil)                  // want "nil" "must be a compile-time constant"
+
+	// variadic
+	Logs("a", "b") // ok
+	Logs("a", s)   // want "the 2th arg of .* must be a compile-time constant"
+
+	// only the constness
+	Any("x") // ok
+	Any(1.1) // ok
+	Any(s)   // want "must be a compile-time constant"
+}
+
+// msg must be a constant string.
+func Log(msg any) {}
+
+// msgs must be constant strings.
+func Logs(msgs ...any) {}
+
+// v must be a constant of any type.
+func Any(v any) {}
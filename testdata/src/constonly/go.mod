@@ -0,0 +1,3 @@
+module constonly
+
+go 1.20
@@ -0,0 +1,68 @@
+package decode
+
+import (
+	"database/sql"
+	"encoding/gob"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"unsafe"
+)
+
+type Payload struct {
+	Name string
+}
+
+type MyErr struct{}
+
+func (*MyErr) Error() string { return "" }
+
+func f(b []byte, r io.Reader, rows *sql.Rows, err error, dst any, param *Payload) {
+	var p Payload
+	json.Unmarshal(b, &p)  // ok
+	json.Unmarshal(b, dst) // ok
+	json.Unmarshal(b, p)   // want `decode.Payload is not allowed for the 2th arg of .*encoding/json.Unmarshal.*: decode.Payload violates non-nil-pointer`
+	json.Unmarshal(b, nil) // want `nil is not allowed for the 2th arg of .*encoding/json.Unmarshal`
+
+	json.NewDecoder(r).Decode(&p) // ok
+	json.NewDecoder(r).Decode(p)  // want `decode.Payload violates non-nil-pointer`
+	xml.Unmarshal(b, p)           // want `decode.Payload violates non-nil-pointer`
+
+	var name string
+	var age int
+	rows.Scan(&name, &age) // ok
+	rows.Scan(&name, age)  // want `int is not allowed for the 2th arg of .*database/sql.Rows.*: int violates non-nil-pointer`
+
+	var pathErr *os.PathError
+	var myErr *MyErr
+	var iface interface{ Timeout() bool }
+	errors.As(err, &pathErr) // ok
+	errors.As(err, &myErr)   // ok
+	errors.As(err, &iface)   // ok
+	errors.As(err, pathErr)  // want `\*.*PathError violates error-target`
+	errors.As(err, &p)       // want `\*decode.Payload violates error-target`
+
+	// typed nil pointers
+	var np *Payload
+	json.Unmarshal(b, np)              // want `nil \*decode.Payload violates non-nil-pointer`
+	json.Unmarshal(b, (*Payload)(nil)) // want `nil \*decode.Payload violates non-nil-pointer`
+	gob.NewDecoder(r).Decode(np)       // want `nil \*decode.Payload violates non-nil-pointer`
+	rows.Scan(&name, (*int)(nil))      // want `nil \*int violates non-nil-pointer`
+	errors.As(err, (*error)(nil))      // want `nil \*error violates error-target`
+	json.Unmarshal(b, param)           // ok because the parameter may be non-nil.
+	json.Unmarshal(b, new(Payload))    // ok
+	var ap *Payload
+	ap = &p
+	json.Unmarshal(b, ap) // ok because ap is assigned.
+
+	// pointers to the wrong kinds
+	fn := func() {}
+	ch := make(chan int)
+	var up unsafe.Pointer
+	json.Unmarshal(b, &fn) // want `\*func\(\) violates non-nil-pointer`
+	json.Unmarshal(b, &ch) // want `\*chan int violates non-nil-pointer`
+	json.Unmarshal(b, &up) // want `\*unsafe.Pointer violates non-nil-pointer`
+	xml.Unmarshal(b, &fn)  // want `\*func\(\) violates non-nil-pointer`
+}
@@ -0,0 +1,117 @@
+package elem
+
+func f(param []any) {
+	// composite literal
+	Exec("q", []any{1, "2"}) // ok
+	Exec("q", []any{1, 2.2}) // want "not allowed"
+	Exec("q", nil)           // ok
+	WithFields(map[string]any{
+		"a": 1,   // ok
+		"b": 2.2, // want "not allowed"
+	})
+
+	// append
+	args := []any{1}
+	args = append(args, "2")
+	Exec("q", args) // ok
+	args2 := []any{1}
+	args2 = append(args2, true) // want "not allowed"
+	Exec("q", args2)
+	args3 := []any{1}
+	Exec("q", append(args3, 1.1)) // want "not allowed"
+
+	// index assignment
+	fields := make(map[string]any)
+	fields["a"] = 1
+	WithFields(fields) // ok
+	var fields2 = map[string]any{}
+	fields2["a"] = 1
+	fields2["b"] = "2"
+	WithFields(fields2) // ok
+	fields3 := make(map[string]any)
+	fields3["a"] = 1.1 // want "not allowed"
+	WithFields(fields3)
+
+	// static element type
+	ExecTyped([]string{"1"})                    // ok
+	ExecTyped([]float64{1})                     // want "float64 is not allowed for the elements"
+	ExecTyped[int]([]int{1})                    // ok
+	WithTypedFields(map[string]bool{"a": true}) // want "bool is not allowed for the elements"
+
+	// untraceable
+	Exec("q", param) // want "not allowed"
+
+	// copied into
+	copied := make([]any, 1)
+	copy(copied, []any{1.1})
+	Exec("q", copied) // want "any is not allowed for the elements"
+	source := []any{1}
+	copy(copied, source)
+	Exec("q", source) // ok because source is only copied from.
+
+	// passed to another function
+	passed := []any{1}
+	modify(passed)
+	Exec("q", passed) // want "any is not allowed for the elements"
+
+	// appended to another variable
+	base := make([]any, 1, 2)
+	other := append(base, 1)
+	other[0] = 1.1
+	Exec("q", base) // want "any is not allowed for the elements"
+
+	// aliased
+	orig := []any{1}
+	alias := orig
+	alias[0] = 1.1
+	Exec("q", orig) // want "any is not allowed for the elements"
+
+	// addressed
+	addressed := []any{1}
+	p := &addressed[0]
+	*p = 1.1
+	Exec("q", addressed) // want "any is not allowed for the elements"
+
+	// zero elements
+	Exec("q", make([]any, 2))    // want "nil is not allowed for the elements"
+	Exec("q", make([]any, 0, 2)) // ok
+	var arr [2]any               // want "nil is not allowed for the elements"
+	ExecArray(arr)
+	var arr2 [2]any // want "nil is not allowed for the elements"
+	arr2[0] = 1
+	ExecArray(arr2)
+	ExecArray([2]any{1})      // want "nil is not allowed for the elements"
+	ExecArray([2]any{1, "2"}) // ok
+	var empty []any
+	empty = append(empty, 1)
+	Exec("q", empty) // ok
+
+	// read only
+	read := []any{1}
+	for _, x := range read {
+		_ = x
+	}
+	if len(read) > 0 && read != nil {
+		_ = read[0]
+	}
+	Exec("q", read) // ok
+}
+
+func modify(args []any) {
+	args[0] = 1.1
+}
+
+// elements of args must be int or string.
+func Exec(query string, args []any) {}
+
+// elements of args must be int or string.
+func ExecArray(args [2]any) {}
+
+// values of fields must be int or string.
+func WithFields(fields map[string]any) {}
+
+// elements of args must be int or string.
+func ExecTyped[T any](args []T) {}
+
+// values of fields must be int or string.
+func WithTypedFields[T any](fields map[string]T) {}
@@ -0,0 +1,3 @@
+module elem
+
+go 1.20
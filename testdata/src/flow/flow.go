@@ -0,0 +1,96 @@
+package flow
+
+func f(cond bool, param any) {
+	// phi
+	var v any
+	if cond {
+		v = 1
+	} else {
+		v = "x"
+	}
+	Target(v) // ok because v is int or string.
+
+	var w any
+	if cond {
+		w = 1.1 // want `float64 \(untyped constant 1.1\) is not allowed`
+	} else {
+		w = "x"
+	}
+	Target(w)
+
+	// flow sensitive
+	u := any(1.1)
+	u = "x"
+	Target(u) // ok because u is string.
+
+	// the assignment reaching the call
+	x := any(1.1)
+	x = "x"
+	if cond {
+		x = 2.2 // want `float64 \(untyped constant 2.2\) is not allowed`
+	}
+	Target(x)
+
+	// nil
+	n := any(1)
+	if cond {
+		n = nil // want "nil is not allowed"
+	}
+	Target(n)
+
+	// zero value
+	var z any // want "nil is not allowed"
+	Target(z)
+
+	// nil is allowed
+	var m any
+	if cond {
+		m = 1
+	}
+	TargetNil(m) // ok because nil is allowed.
+
+	// values
+	l := any("trace")
+	l = "debug"
+	Level(l) // ok because l is "debug".
+	if cond {
+		l = "trace" // want `string \(value "trace"\) is not allowed`
+	}
+	Level(l)
+
+	// untyped constants
+	var fl any = 1
+	Float(fl) // ok because 1 is representable as float64.
+	fl = "1"  // want `string \(untyped constant "1"\) is not allowed`
+	Float(fl)
+
+	// change interface
+	var s interface{ String() string }
+	s = str("x")
+	Target(s) // ok because str is allowed.
+
+	// untraced
+	Target(param) // want "any is not allowed"
+
+	// static check without data flow
+	NoFlow(v) // want "any is not allowed"
+}
+
+// v must be int, string, or str.
+func Target(v any) {}
+
+// v must be int, string, or str.
+func NoFlow(v any) {}
+
+// v must be int or nil.
+func TargetNil(v any) {}
+
+// v must be "debug" or "info".
+func Level(v any) {}
+
+// v must be representable as float64.
+func Float(v any) {}
+
+type str string
+
+func (s str) String() string { return string(s) }
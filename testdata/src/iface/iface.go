@@ -0,0 +1,56 @@
+package iface
+
+import "time"
+
+func f(v any, err error) {
+	// reject
+	Reject(v) // want "any is not allowed"
+
+	// accept
+	Accept(v)   // ok
+	Accept(1.1) // want `float64 \(untyped constant 1.1\) is not allowed`
+
+	// narrowed by type switch
+	switch v.(type) {
+	case int, string:
+		Narrowed(v) // ok
+	case float64:
+		Narrowed(v) // want "any is not allowed"
+	default:
+		Narrowed(v) // want "any is not allowed"
+	}
+	switch w := v.(type) {
+	case int, string:
+		Narrowed(w) // ok
+	case bool, time.Time:
+		Narrowed(w) // want "any is not allowed"
+	}
+
+	// narrowed by type assertion
+	if _, ok := v.(int); ok {
+		Narrowed(v) // ok
+	}
+	if _, ok := v.(float64); ok {
+		Narrowed(v) // want "any is not allowed"
+	}
+	Narrowed(v) // want "any is not allowed"
+
+	// assume
+	//notany:assume int
+	Assume(v) // ok
+	Assume(v) //notany:assume string
+	Assume(v) //notany:assume time.Time // want "any is not allowed"
+	Assume(v) // want "any is not allowed"
+}
+
+// v must be int or string.
+func Reject(v any) {}
+
+// v must be int or string.
+func Accept(v any) {}
+
+// v must be int or string.
+func Narrowed(v any) {}
+
+// v must be int or string.
+func Assume(v any) {}
@@ -0,0 +1,21 @@
+package config
+
+import "time"
+
+const (
+	//notany:key time.Duration
+	KeyTimeout = "timeout"
+	KeyRetries = "retries" //notany:key int
+	KeyName    = "name"
+)
+
+var _ time.Duration
+
+// v must be of the type selected by key.
+func SetValue(key string, v any) {}
+
+// v must be a pointer-free type selected by key.
+func SetPointerFree(key string, v any) {}
+
+// v must be a level selected by key.
+func SetLevel(key string, v any) {}
@@ -0,0 +1,46 @@
+package keys
+
+import (
+	"time"
+
+	"keys/config"
+)
+
+//notany:key bool
+const keyDebug = "debug" // want keyDebug:"key\\(bool\\)"
+
+//notany:key string
+const otherRetries = "retries" // want otherRetries:"key\\(string\\)"
+
+const keyBad = "bad" //notany:key nosuch.Type // want `invalid type nosuch.Type in //notany:key` keyBad:"key\\(\\)"
+
+func f(key string) {
+	// directives in another package
+	config.SetValue(config.KeyTimeout, time.Second) // ok
+	config.SetValue(config.KeyTimeout, 1)           // want `int \(untyped constant 1\) is not allowed for the 2th arg of .* with key "timeout"`
+	config.SetValue("retries", 3)                   // ok
+	config.SetValue(config.KeyRetries, "3")         // want `string \(untyped constant "3"\) is not allowed for the 2th arg of .* with key "retries"`
+
+	// directive in the same package
+	config.SetValue(keyDebug, true)    // ok
+	config.SetValue(keyDebug, 1)       // want `with key "debug"`
+	config.SetValue("debug", true)     // want `unknown key "debug"`
+	config.SetValue(otherRetries, "3") // ok because the directive of the passed constant is used.
+	config.SetValue(keyBad, 1)         // want `with key "bad"`
+
+	// configured
+	config.SetValue(config.KeyName, "x") // ok
+	config.SetValue("name", 1)           // want `with key "name"`
+
+	// policies of the target
+	config.SetPointerFree("count", 1)     // ok
+	config.SetPointerFree("count", "str") // want `string \(untyped constant "str"\) is not allowed for the 2th arg of .* with key "count"`
+	config.SetLevel("level", 1)           // ok
+	config.SetLevel("level", 3)           // want `int \(value 3\) is not allowed for the 2th arg of .* with key "level"`
+
+	// unknown key
+	config.SetValue("unknown", 1) // want `unknown key "unknown" for the 1th arg`
+
+	// non-constant key
+	config.SetValue(key, 1) // want "the 1th arg of .* must be a constant key"
+}
@@ -0,0 +1,61 @@
+package match
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+)
+
+type MyString string
+
+type MyInt int
+
+type Celsius float64
+
+type ReadCloser interface {
+	io.Reader
+	io.Closer
+}
+
+func f(r io.Reader, rc ReadCloser, f *os.File, err error, s fmt.Stringer) {
+	Underlying("str")         // ok
+	Underlying(MyString("s")) // ok
+	Underlying(1)             // want `int \(untyped constant 1\) is not allowed for the 1th arg`
+	Underlying(MyInt(1))      // want `match.MyInt is not allowed for the 1th arg`
+
+	Assignable(r)     // ok
+	Assignable(rc)    // ok
+	Assignable(f)     // ok
+	Assignable(s)     // want `fmt.Stringer is not allowed for the 1th arg`
+	Assignable("str") // want `string \(untyped constant "str"\) is not allowed for the 1th arg`
+
+	Convertible(1)          // ok
+	Convertible(MyInt(1))   // ok
+	Convertible(Celsius(1)) // ok
+	Convertible("str")      // want `string \(untyped constant "str"\) is not allowed for the 1th arg`
+
+	Exact(err)             // ok
+	Exact(errors.New("x")) // ok
+	Exact(&os.PathError{}) // want `\*.*PathError is not allowed for the 1th arg`
+	Exact(fmt.Errorf("x")) // ok
+
+	Values(MyString("debug")) // ok
+	Values(MyString("trace")) // want `match.MyString \(value "trace"\) is not allowed for the 1th arg`
+
+	AnyOf(MyString("trace")) // ok because MyString is allowed without values
+	AnyOf("debug")           // ok
+	AnyOf("trace")           // want `string \(value "trace"\) is not allowed for the 1th arg`
+}
+
+func Underlying(v any) {}
+
+func Assignable(v any) {}
+
+func Convertible(v any) {}
+
+func Exact(v any) {}
+
+func Values(v any) {}
+
+func AnyOf(v any) {}
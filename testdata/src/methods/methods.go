@@ -0,0 +1,37 @@
+package methods
+
+import (
+	"log/slog"
+	"time"
+
+	"methods/store"
+)
+
+type Level int
+
+func (Level) LogValue() slog.Value { return slog.Value{} }
+
+type Secret struct{}
+
+func (*Secret) LogValue() slog.Value { return slog.Value{} }
+
+type Name string
+
+func (n Name) MarshalText() ([]byte, error) { return []byte(n), nil }
+
+type Wrong struct{}
+
+func (Wrong) LogValue() string { return "" }
+
+func f() {
+	Log(Level(1))     // ok
+	Log(&Secret{})    // ok
+	Log(Name("x"))    // ok
+	Log(time.Now())   // ok because time.Time has MarshalText
+	Log(Secret{})     // want `methods.Secret is not allowed for the 1th arg of .*methods.Log.*: \*methods.Secret implements interface{LogValue\(\) log/slog.Value} with pointer receivers`
+	Log(Wrong{})      // want `methods.Wrong is not allowed for the 1th arg of .*methods.Log`
+	Log(store.Item{}) // ok because unexported methods are matched by their names
+	Log(1)            // want `int \(untyped constant 1\) is not allowed for the 1th arg of .*methods.Log`
+}
+
+func Log(v any) {}
@@ -0,0 +1,5 @@
+package store
+
+type Item struct{}
+
+func (Item) key() string { return "" }
@@ -0,0 +1,3 @@
+module notvar
+
+go 1.20
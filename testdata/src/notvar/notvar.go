@@ -0,0 +1,3 @@
+package notvar
+
+var Count int
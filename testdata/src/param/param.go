@@ -0,0 +1,20 @@
+package param
+
+func f() {
+	// single parameter before the variadic one
+	Log(1, 1.1)        // ok because attrs are not limited.
+	Log(1.1, "attr")   // want "not allowed"
+	Log("msg", nil, 1) // ok
+
+	// variadic elements
+	Tail(1.1, 1, 2)   // ok because msg is not limited.
+	Tail(1.1, 1, 2.2) // want "not allowed"
+}
+
+// msg must be string or int.
+func Log(msg any, attrs ...any) {}
+
+// attrs must be string or int.
+func Tail(msg any, attrs ...any) {}
+
+func Concrete(msg string, attrs ...any) {}
@@ -0,0 +1,3 @@
+module pattern
+
+go 1.21
@@ -0,0 +1,46 @@
+package pattern
+
+import (
+	"log/slog"
+	"time"
+)
+
+func f(attrs []any) {
+	slog.Info("msg")                                                   // ok
+	slog.Info("msg", "key", 1, "duration", time.Second)                // ok
+	slog.Info("msg", slog.Int("key", 1), "duration", time.Second)      // ok
+	slog.Info("msg", "key", 1, slog.Duration("duration", time.Second)) // ok
+	slog.Info("msg", []any{"key", 1}...)                               // ok
+	slog.Info("msg", []any{slog.Int("key", 1), "key", 1}...)           // ok
+
+	slog.Info("msg", 1, 1)                                // want `int \(untyped constant 1\) is not allowed for the key at the 2th arg of .*log/slog.Info`
+	slog.Info("msg", "key", struct{}{})                   // want `struct{} is not allowed for the value at the 3th arg of .*log/slog.Info`
+	slog.Info("msg", "key", slog.Int("key", 1))           // want `log/slog.Attr is not allowed for the value at the 3th arg of .*log/slog.Info`
+	slog.Info("msg", "key")                               // want `dangling key at the 2th arg of .*log/slog.Info`
+	slog.Info("msg", "key", 1, slog.Int("key", 1), "key") // want `dangling key at the 5th arg of .*log/slog.Info`
+
+	Pairs(1, "a", 2) // want `dangling slot 0 at the 3th arg of .*pattern.Pairs`
+	Pairs(1, 2)      // want `int \(untyped constant 2\) is not allowed for the slot 1 at the 2th arg of .*pattern.Pairs`
+
+	// spread arguments
+	slog.Info("msg", []any{1, 1}...)  // want `int \(untyped constant 1\) is not allowed for the key at the 2th arg of .*log/slog.Info`
+	slog.Info("msg", []any{"key"}...) // want `dangling key at the 2th arg of .*log/slog.Info`
+	slog.Info("msg", attrs...)        // want `any is not allowed for the elements of the 2th arg of .*log/slog.Info`
+	Pairs(attrs...)                   // want `any is not allowed for the elements of the 1th arg of .*pattern.Pairs`
+	//notany:trust
+	Pairs(attrs...) // ok
+}
+
+func g[S ~[]any](args S) {
+	slog.Info("msg", args...) // want `any is not allowed for the elements of the 2th arg of .*log/slog.Info`
+}
+
+type attrList interface {
+	~[]any
+}
+
+func h[S attrList](args S) {
+	Pairs(args...) // want `any is not allowed for the elements of the 1th arg of .*pattern.Pairs`
+}
+
+func Pairs(args ...any) {}
@@ -0,0 +1,3 @@
+module predicate
+
+go 1.20
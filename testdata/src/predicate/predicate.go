@@ -0,0 +1,90 @@
+package predicate
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+type Payload struct {
+	Name  string
+	Items []Item
+	Meta  map[string]any
+	At    time.Time
+	ch    chan int
+}
+
+type Item struct {
+	ID    int
+	Done  func()
+	Price complex128
+}
+
+type Node struct {
+	Value    int
+	Children []*Node
+	Parent   *Node
+}
+
+type Tree struct {
+	Root Node
+	Fn   func()
+}
+
+type Counter struct {
+	mu sync.Mutex
+	n  int
+}
+
+type Keyed struct {
+	M map[Point]int
+}
+
+type Point struct {
+	X, Y  int
+	Label string
+	Grid  [2][2]float64
+}
+
+type Shared struct {
+	Point Point
+	Next  *Point
+}
+
+func f(v any) {
+	json.Marshal(Payload{})      // want `predicate.Payload is not allowed for the 1th arg of .*encoding/json.Marshal.*: .Items\[\].Done \(func\(\)\) violates marshalable`
+	json.Marshal(Node{})         // ok
+	json.Marshal(Keyed{})        // want `.M\[key\] \(predicate.Point\) violates marshalable`
+	json.Marshal(make(chan int)) // want `chan int violates marshalable`
+	json.Marshal(v)              // ok
+	json.Marshal(Point{})        // ok
+
+	Set("k", Node{})     // ok
+	Set("k", Tree{})     // want `.Fn \(func\(\)\) violates no-func-chan`
+	Set("k", Counter{})  // want `.mu \(sync.Mutex\) violates no-locks`
+	Set("k", &Counter{}) // ok
+	Set("k", Payload{})  // want `.Items\[\].Done \(func\(\)\) violates no-func-chan`
+
+	Copy(Point{})    // ok
+	Copy(Shared{})   // want `.Next \(\*predicate.Point\) violates pointer-free`
+	Copy([]int{})    // want `\[\]int violates pointer-free`
+	Copy(1)          // ok
+	CopyInt(Point{}) // want `predicate.Point is not allowed for the 1th arg of .*predicate.CopyInt`
+	CopyInt(1)       // ok
+}
+
+func Set(key string, v any) {}
+
+func Copy(v any) {}
+
+func CopyInt(v any) {}
+
+type Embedded struct {
+	sync.RWMutex
+}
+
+func g() {
+	Set("k", Embedded{})          // want `.RWMutex \(sync.RWMutex\) violates no-locks`
+	Set("k", []Counter{})         // ok
+	Set("k", [1]sync.WaitGroup{}) // want `\[\]\.noCopy \(sync.noCopy\) violates no-locks`
+}
@@ -0,0 +1,3 @@
+module relation
+
+go 1.20
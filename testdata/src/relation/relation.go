@@ -0,0 +1,26 @@
+package relation
+
+import "fmt"
+
+type MyInt int
+
+func f(i int, i64 int64, err error, s fmt.Stringer) {
+	// identical
+	Equal(nil, 1, i)        // ok
+	Equal(nil, 1, i64)      // want `the 2th arg \(int\) and the 3th arg \(int64\) of .* must be identical`
+	Equal(nil, MyInt(1), i) // want `the 2th arg \(relation.MyInt\) and the 3th arg \(int\) of .* must be identical`
+	Equal(nil, nil, err)    // ok because nil is not subject to the relationship.
+	Equal(nil, "a", "b")    // ok
+
+	// assignable
+	Assignable(s, str("")) // ok because str is assignable to fmt.Stringer.
+	Assignable(str(""), s) // want `the 2th arg \(fmt.Stringer\) of .* must be assignable to the 1th arg \(relation.str\)`
+}
+
+func Equal(t any, expected, actual any) {}
+
+func Assignable(x, y any) {}
+
+type str string
+
+func (s str) String() string { return string(s) }
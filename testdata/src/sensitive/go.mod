@@ -0,0 +1,3 @@
+module sensitive
+
+go 1.20
@@ -0,0 +1,15 @@
+package secret
+
+//notany:sensitive
+type Password string // want Password:"sensitive"
+
+type (
+	Token struct { // want Token:"sensitive"
+		Value string
+	} //notany:sensitive
+)
+
+type User struct {
+	Name     string
+	Password Password
+}
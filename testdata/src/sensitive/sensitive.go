@@ -0,0 +1,50 @@
+package sensitive
+
+import (
+	"fmt"
+
+	"sensitive/secret"
+)
+
+//notany:sensitive
+type pii struct{} // want pii:"sensitive"
+
+type Account struct {
+	Users []secret.User
+}
+
+type Pw = secret.Password
+
+var Events chan any
+
+func f(p secret.Password, u *secret.User, a Account, tok secret.Token) {
+	Log("name")    // ok
+	Log(p)         // want `sensitive/secret.Password is not allowed for the 1th arg of .*sensitive.Log.*: sensitive/secret.Password is sensitive`
+	Log(string(p)) // ok
+	Log(u)         // want `\.Password \(sensitive/secret.Password\) is sensitive`
+	Log(a)         // want `\.Users\[\]\.Password \(sensitive/secret.Password\) is sensitive`
+	Log(&tok)      // want `sensitive/secret.Token is sensitive`
+	Log(pii{})     // want `sensitive.pii is sensitive`
+	Log(Pw(p))     // want `sensitive/secret.Password is sensitive`
+	Log([]Pw{})    // want `\[\] \(sensitive/secret.Password\) is sensitive`
+
+	Pairs("k", p)             // want `sensitive/secret.Password is sensitive`
+	Pairs([]any{"k", p}...)   // want `sensitive/secret.Password is sensitive`
+	Pairs([]any{"k", "v"}...) // ok
+
+	fmt.Println("x", p) // want `sensitive/secret.Password is sensitive`
+	fmt.Println("x", 1) // ok
+
+	Events <- 1 // ok
+	Events <- p // want `sensitive/secret.Password is not allowed to be sent to Events`
+
+	var v any = p // want `sensitive/secret.Password is sensitive`
+	Trace(v)
+	Log(v) // ok because only the static type is checked without Flow
+}
+
+func Log(v any) {}
+
+func Trace(v any) {}
+
+func Pairs(args ...any) {}
@@ -0,0 +1,53 @@
+package size
+
+type Small struct {
+	A, B int32
+}
+
+type Large struct {
+	Buf [16]int64
+}
+
+type Wrapper struct {
+	P *Large
+}
+
+func f(err error, l Large, v any) {
+	Record("k", Small{})    // ok
+	Record("k", 1)          // ok
+	Record("k", Large{})    // want `size.Large is not allowed for the 2th arg of .*size.Record.*: size.Large has 128 bytes exceeding the max size of 64 bytes`
+	Record("k", [9]int64{}) // want `\[9\]int64 has 72 bytes exceeding the max size of 64 bytes`
+	Record("k", &l)         // ok
+	Record("k", err)        // ok
+	Record("k", nil)        // want "nil is not allowed"
+
+	Tag(&l)               // ok
+	Tag(Wrapper{})        // ok
+	Tag(struct{}{})       // ok
+	Tag(err)              // ok
+	Tag(map[string]int{}) // ok
+	Tag(Small{})          // want `size.Small \(8 bytes\) violates pointer-shaped`
+	Tag("str")            // want `string \(16 bytes\) violates pointer-shaped`
+	Tag(nil)              // want "nil is not allowed"
+	TagNil(nil)           // ok
+
+	// nil narrowed by type switch
+	switch v.(type) {
+	case nil:
+		RecordNarrowed(v) // want "any is not allowed"
+		TagNil(v)         // ok
+	case Small:
+		RecordNarrowed(v) // ok
+		TagNil(v)         // want "any is not allowed"
+	}
+}
+
+func Record(name string, v any) {}
+
+func Tag(v any) {}
+
+// v must be size.Small.
+func RecordNarrowed(v any) {}
+
+// v must be *size.Large or nil.
+func TagNil(v any) {}
@@ -0,0 +1,34 @@
+package spread
+
+func f(param []any) {
+	// traced
+	args := []any{1, "2"}
+	Report(args...)          // ok
+	Report([]any{1, 2.2}...) // want "not allowed"
+	args2 := []any{1}
+	args2 = append(args2, true) // want "not allowed"
+	Report(args2...)
+
+	// untraced
+	Report(param...)    // want "not allowed"
+	Trust(param...)     // ok
+	Annotated(param...) // want "not allowed"
+	//notany:trust
+	Annotated(param...) // ok
+	//notany:trust
+	defer Annotated(param...) // ok
+	Annotated(param...)       //notany:trust
+	Annotated(param...)       // want "not allowed"
+
+	// traced elements are checked regardless of the policy.
+	Trust([]any{1.1}...) // want "not allowed"
+}
+
+// args must be int or string.
+func Report(args ...any) {}
+
+// args must be int or string.
+func Trust(args ...any) {}
+
+// args must be int or string.
+func Annotated(args ...any) {}
@@ -0,0 +1,27 @@
+package tuple
+
+func f() {
+	// non-variadic
+	Target(intString()) // ok
+	Target(intFloat())  // want "not allowed"
+
+	// variadic
+	Variadic(intString())   // ok
+	Variadic(intFloat())    // want "not allowed"
+	Variadic(floatString()) // ok because the 1st arg is not limited.
+	Variadic(intOnly())     // ok because the 2nd arg is not passed.
+}
+
+// b must be string.
+func Target(a any, b any) {}
+
+// b must be string.
+func Variadic(a any, b ...any) {}
+
+func intString() (int, string) { return 0, "" }
+
+func intFloat() (int, float64) { return 0, 0 }
+
+func floatString() (float64, string) { return 0, "" }
+
+func intOnly() int { return 0 }
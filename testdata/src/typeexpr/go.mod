@@ -0,0 +1,3 @@
+module typeexpr
+
+go 1.21
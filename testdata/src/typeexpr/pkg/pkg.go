@@ -0,0 +1,9 @@
+package pkg
+
+type Item struct{}
+
+type Event struct{}
+
+type Option[T any] struct{}
+
+type Pair[K comparable, V any] struct{}
@@ -0,0 +1,38 @@
+package typeexpr
+
+import (
+	"errors"
+
+	"typeexpr/pkg"
+)
+
+type OS = pkg.Option[string]
+
+func f(ch chan pkg.Event, send chan<- pkg.Event) {
+	Do([]byte("x"))                 // ok
+	Do([]uint8("x"))                // ok
+	Do(map[string]string{})         // ok
+	Do([]*pkg.Item{})               // ok
+	Do(func() error { return nil }) // ok
+	Do(send)                        // ok
+	Do(pkg.Option[int]{})           // ok
+	Do(pkg.Pair[string, bool]{})    // ok
+	Do(pkg.Pair[string, error]{})   // ok
+	Do(new(int))                    // ok
+	Do(errors.New)                  // want `func\(text string\) error is not allowed for the 1th arg`
+	Do(ch)                          // want `chan typeexpr/pkg.Event is not allowed for the 1th arg`
+	Do([]pkg.Item{})                // want `\[\]typeexpr/pkg.Item is not allowed for the 1th arg`
+	Do(map[string]int{})            // want `map\[string\]int is not allowed for the 1th arg`
+	Do(pkg.Option[string]{})        // want `typeexpr/pkg.Option\[string\] is not allowed for the 1th arg`
+	Do(pkg.Pair[int, bool]{})       // want `typeexpr/pkg.Pair\[int, bool\] is not allowed for the 1th arg`
+	Do(new(string))                 // want `\*string is not allowed for the 1th arg`
+
+	Any(pkg.Option[string]{})          // ok
+	Any(pkg.Option[pkg.Option[int]]{}) // ok
+	Any(OS{})                          // ok
+	Any(pkg.Item{})                    // want `typeexpr/pkg.Item is not allowed for the 1th arg`
+}
+
+func Do(v any) {}
+
+func Any(v any) {}
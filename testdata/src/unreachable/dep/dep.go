@@ -0,0 +1,8 @@
+package dep
+
+import "fmt"
+
+// args are forwarded to fmt.Println without importing unreachable/types.
+func Print(args ...any) {
+	fmt.Println(args...)
+}
@@ -0,0 +1,3 @@
+module unreachable
+
+go 1.20
@@ -0,0 +1,14 @@
+package unreachable
+
+import (
+	"fmt"
+	"unreachable/dep"
+	"unreachable/types"
+)
+
+func f() {
+	fmt.Println(types.MyInt(1)) // ok
+	fmt.Println("str")          // want "not allowed"
+	dep.Print(types.MyInt(1))   // ok
+	dep.Print("str")            // want "not allowed"
+}
@@ -0,0 +1,3 @@
+module untyped
+
+go 1.20
@@ -0,0 +1,47 @@
+package untyped
+
+const one = 1
+
+const typedOne float32 = 1
+
+func f() {
+	// nil
+	AllowNil(nil)      // ok
+	NilEntry(nil)      // ok
+	Default(nil)       // want "nil is not allowed"
+	Representable(nil) // want "nil is not allowed"
+
+	// default type
+	Default(1)          // want `int \(untyped constant 1\) is not allowed`
+	Default(1.1)        // ok
+	Default(one)        // want `int \(untyped constant one = 1\) is not allowed`
+	Default(float32(1)) // want "float32 is not allowed"
+
+	// representable
+	Representable(1)         // ok because 1 is representable as float64.
+	Representable(one + 1)   // ok
+	Representable(1.1)       // ok
+	Representable("str")     // want `string \(untyped constant "str"\) is not allowed`
+	Representable("s" + "t") // want `string \(untyped constant "s" \+ "t" = "st"\) is not allowed`
+	Representable(typedOne)  // want "float32 is not allowed"
+	Int8(127)                // ok
+	Int8(128)                // want "not allowed"
+	Int8(-128)               // ok
+	Int8(1.0)                // ok because 1.0 is representable as int8.
+	Int8(1.5)                // want "not allowed"
+}
+
+// v must be float64.
+func AllowNil(v any) {}
+
+// v must be float64 or nil.
+func NilEntry(v any) {}
+
+// v must be float64.
+func Default(v any) {}
+
+// v must be float64.
+func Representable(v any) {}
+
+// v must be int8.
+func Int8(v any) {}
@@ -0,0 +1,14 @@
+package option
+
+type Level int
+
+const (
+	LevelDebug Level = iota
+	LevelInfo
+)
+
+const (
+	NameTimeout = "timeout"
+	NameRetries = "retries"
+	Other       = "other"
+)
@@ -0,0 +1,64 @@
+package values
+
+import "values/option"
+
+func f(s string, n int, opts []string) {
+	// explicit set
+	SetOption("debug")   // ok
+	SetOption("verbose") // ok
+	SetOption("fatal")   // want `string \(value "fatal"\) is not allowed`
+	SetOption(s)         // want `string \(non-constant\) is not allowed`
+	SetOption(1)         // want "int"
+
+	// constant group
+	SetName(option.NameTimeout) // ok
+	SetName("retries")          // ok
+	SetName(option.Other)       // want `string \(value "other"\) is not allowed`
+	SetLevel(option.LevelInfo)  // ok
+	SetLevel(option.Level(5))   // want `values/option.Level \(value 5\) is not allowed`
+
+	// range
+	SetPort(8080)  // ok
+	SetPort(0)     // want `int \(value 0\) is not allowed`
+	SetPort(65536) // want `int \(value 65536\) is not allowed`
+	SetPort(n)     // ok because non-constant is accepted.
+
+	// elements
+	SetOptions("debug", "verbose")         // ok
+	SetOptions("fatal")                    // want `string \(value "fatal"\) is not allowed`
+	SetOptions([]any{"debug", "fatal"}...) // want `string \(value "fatal"\) is not allowed for the elements`
+	SetOptionList([]string{"debug"})       // ok
+	SetOptionList([]string{"fatal"})       // want `string \(value "fatal"\) is not allowed for the elements`
+	SetOptionList(opts)                    // want `string \(non-constant\) is not allowed for the elements`
+	SetPorts([]any{8080, 0})               // want `int \(value 0\) is not allowed for the elements`
+
+	// untyped constants representable as the allowed type
+	SetInt8(1)       // ok
+	SetInt8(3)       // want `int \(untyped constant 3\) is not allowed`
+	SetInt8(int8(2)) // ok
+	SetInt8(int8(3)) // want `int8 \(value 3\) is not allowed`
+}
+
+// v must be 1 or 2 of int8.
+func SetInt8(v any) {}
+
+// v must be "debug" or "verbose".
+func SetOption(v any) {}
+
+// v must be a name declared in option.
+func SetName(v any) {}
+
+// v must be a level declared in option.
+func SetLevel(v any) {}
+
+// v must be in [1, 65535].
+func SetPort(v any) {}
+
+// opts must be "debug" or "verbose".
+func SetOptions(opts ...any) {}
+
+// opts must be "debug" or "verbose".
+func SetOptionList[T any](opts []T) {}
+
+// ports must be in [1, 65535].
+func SetPorts(ports []any) {}
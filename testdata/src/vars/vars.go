@@ -0,0 +1,73 @@
+package vars
+
+import "fmt"
+
+func f() {
+	// channel
+	Events <- 1      // ok
+	Events <- "str"  // ok
+	Events <- 1.1    // want "not allowed"
+	(Events) <- true // want "not allowed"
+
+	// channel field
+	var b Bus
+	b.Events <- MyInt(1) // ok
+	b.Events <- 1        // want "not allowed"
+	b.Events = nil       // ok because the channel itself is not checked.
+
+	// variable
+	Hook = 1               // ok
+	Hook = "str"           // ok
+	Hook = 1.1             // want "not allowed"
+	Hook, Other = 1.1, 1.1 // want "not allowed"
+	Other = 1.1            // ok
+	Hook = nil             // ok because nil is allowed.
+	Hook, Other = pair()   // want "float64 is not allowed to be assigned to Hook"
+	Other, Hook = pair()   // ok
+	var ok bool
+	Hook, ok = floats["a"] // want "float64 is not allowed to be assigned to Hook"
+	_ = ok
+
+	// untyped constant
+	Floats <- 1   // ok because 1 is representable as float64.
+	Floats <- "1" // want "not allowed"
+
+	// value
+	Level = "debug" // ok
+	Level = "trace" // want `string \(value "trace"\) is not allowed to be assigned to Level`
+
+	// not limited
+	v := 1.1
+	v = 2.2
+	fmt.Println(v)
+}
+
+// Events must receive int or string.
+var Events chan any
+
+// Hook must be int or string.
+var Hook any
+
+var Other any
+
+// Init must be int or string.
+var Init any = 1.1 // want "float64 \\(untyped constant 1.1\\) is not allowed to be assigned to Init"
+
+// Floats must receive float64.
+var Floats chan any
+
+// Level must be "debug" or "info".
+var Level any
+
+var floats map[string]float64
+
+func pair() (float64, int) {
+	return 1.1, 1
+}
+
+type Bus struct {
+	// Events must receive MyInt.
+	Events chan any
+}
+
+type MyInt int
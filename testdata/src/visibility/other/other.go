@@ -0,0 +1,7 @@
+package other
+
+type Key struct{}
+
+type key struct{}
+
+var Unexported = key{}
@@ -0,0 +1,39 @@
+package visibility
+
+import (
+	"context"
+	"time"
+
+	"visibility/other"
+)
+
+type ctxKey struct{}
+
+type CtxKey struct{}
+
+type K = ctxKey
+
+func f(ctx context.Context) {
+	context.WithValue(ctx, ctxKey{}, 1)         // ok
+	context.WithValue(ctx, &ctxKey{}, 1)        // ok
+	context.WithValue(ctx, K{}, 1)              // ok
+	context.WithValue(ctx, &K{}, 1)             // ok
+	context.WithValue(ctx, CtxKey{}, 1)         // want `visibility.CtxKey violates unexported`
+	context.WithValue(ctx, other.Unexported, 1) // want `visibility/other.key violates caller-package`
+	context.WithValue(ctx, "key", 1)            // want `string violates caller-package`
+	context.WithValue(ctx, struct{}{}, 1)       // want `struct{} violates caller-package`
+
+	Send(CtxKey{})    // ok
+	Send(other.Key{}) // ok
+	Send(time.Second) // want `time.Duration violates caller-module`
+	Send(1)           // want `int violates caller-module`
+
+	Publish(CtxKey{})   // ok
+	Publish(1)          // ok
+	Publish(ctxKey{})   // want `visibility.ctxKey violates exported`
+	Publish([]CtxKey{}) // want `\[\]visibility.CtxKey violates exported`
+}
+
+func Send(v any) {}
+
+func Publish(v any) {}
@@ -0,0 +1,7 @@
+package events
+
+type UserCreated struct{}
+
+type OrderPlaced struct{}
+
+type Wrapped[T any] struct{}
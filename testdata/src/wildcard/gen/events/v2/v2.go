@@ -0,0 +1,3 @@
+package v2
+
+type UserDeleted struct{}
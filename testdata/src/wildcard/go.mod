@@ -0,0 +1,3 @@
+module wildcard
+
+go 1.21
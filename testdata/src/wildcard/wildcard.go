@@ -0,0 +1,33 @@
+package wildcard
+
+import (
+	"wildcard/gen/events"
+	v2 "wildcard/gen/events/v2"
+	"wildcard/gen/other"
+)
+
+type UC = events.UserCreated
+
+type UCPtr = *events.UserCreated
+
+func f() {
+	Publish(events.UserCreated{})  // ok
+	Publish(events.OrderPlaced{})  // ok
+	Publish(events.Wrapped[int]{}) // ok
+	Publish(UC{})                  // ok
+	Publish(&events.UserCreated{}) // want `\*wildcard/gen/events.UserCreated is not allowed for the 1th arg`
+	Publish(v2.UserDeleted{})      // want `wildcard/gen/events/v2.UserDeleted is not allowed for the 1th arg`
+	Publish(other.Message{})       // want `wildcard/gen/other.Message is not allowed for the 1th arg`
+	Publish(1)                     // want `int \(untyped constant 1\) is not allowed for the 1th arg`
+
+	PublishPtr(&events.UserCreated{}) // ok
+	PublishPtr(&v2.UserDeleted{})     // ok
+	PublishPtr(&UC{})                 // ok
+	PublishPtr(UCPtr(nil))            // ok
+	PublishPtr(v2.UserDeleted{})      // want `wildcard/gen/events/v2.UserDeleted is not allowed for the 1th arg`
+	PublishPtr(&other.Message{})      // want `\*wildcard/gen/other.Message is not allowed for the 1th arg`
+}
+
+func Publish(msg any) {}
+
+func PublishPtr(msg any) {}
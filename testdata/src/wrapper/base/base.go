@@ -0,0 +1,13 @@
+package base
+
+// v must be int or string.
+func Info(msg string, v any) {}
+
+// v must be int or nil.
+func Nilable(v any) {}
+
+// v must be a constant string.
+func Const(v any) {}
+
+// args must be int or string.
+func Logf(format string, args ...any) {}
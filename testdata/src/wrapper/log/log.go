@@ -0,0 +1,38 @@
+package log
+
+import "wrapper/base"
+
+// v is forwarded to base.Info.
+func Info(msg string, v any) {
+	base.Info(msg, v)
+}
+
+// v is forwarded to Info.
+func Infof(v any) {
+	Info("msg", v)
+}
+
+type Logger struct{}
+
+// v is forwarded to base.Info.
+func (l *Logger) Info(v any) {
+	base.Info("msg", v)
+}
+
+// v is forwarded to base.Nilable.
+func Nilable(v any) {
+	base.Nilable(v)
+}
+
+// v is forwarded to base.Const.
+func Const(v any) {
+	base.Const(v)
+}
+
+// args are forwarded to base.Logf.
+func Logf(format string, args ...any) {
+	if len(args) == 0 {
+		return
+	}
+	base.Logf(format, args...)
+}
@@ -0,0 +1,60 @@
+package wrapper
+
+import (
+	"wrapper/base"
+	"wrapper/log"
+)
+
+func f(cond bool) {
+	// wrappers in other packages
+	log.Info("msg", 1)   // ok
+	log.Info("msg", 1.1) // want `float64 \(untyped constant 1.1\) is not allowed`
+	log.Infof("x")       // ok
+	log.Infof(true)      // want `bool \(untyped constant true\) is not allowed`
+	var l log.Logger
+	l.Info(1.1) // want `float64 \(untyped constant 1.1\) is not allowed`
+
+	// wrapper in the same package
+	info(1)   // ok
+	info(1.1) // want `float64 \(untyped constant 1.1\) is not allowed`
+
+	// the policy of the target is inherited
+	log.Nilable(nil) // ok because nil is allowed.
+	log.Nilable("x") // want `string \(untyped constant "x"\) is not allowed`
+	nilable(nil)     // ok because nil is allowed.
+	log.Const("x")   // ok
+	s := "x"
+	log.Const(s) // want "must be a compile-time constant"
+
+	// variadic parameters spread into the target
+	log.Logf("x", 1, "a")      // ok
+	log.Logf("x", 1, 1.1)      // want `float64 \(untyped constant 1.1\) is not allowed`
+	logf("x", true)            // want `bool \(untyped constant true\) is not allowed`
+	log.Logf("x", []any{1}...) // ok
+}
+
+func info(v any) { // want info:"wrapper\\(0:\\[int string\\]\\)"
+	base.Info("msg", v)
+}
+
+func nilable(v any) { // want nilable:"wrapper\\(0:\\[int nil\\]\\)"
+	base.Nilable(v)
+}
+
+func logf(format string, args ...any) { // want logf:"wrapper\\(1:\\[int string\\]\\)"
+	log.Logf(format, args...)
+}
+
+// modified variadic parameter is not forwarded unchanged.
+func modify(args ...any) {
+	args[0] = 1.1
+	base.Logf("x", args...) // want "any is not allowed"
+}
+
+// reassigned parameter is not forwarded unchanged.
+func reassign(v any, cond bool) {
+	if cond {
+		v = 1
+	}
+	base.Info("msg", v) // want "any is not allowed"
+}
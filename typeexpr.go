@@ -0,0 +1,285 @@
+package notany
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"regexp"
+	"strings"
+
+	"github.com/qawatake/notany/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// wildcardArg matches * used as a type argument such as Option[*].
+var wildcardArg = regexp.MustCompile(`([\[,]\s*)\*(\s*[\],])`)
+
+// evalTypeExpr returns the type denoted by the type expression expr such as []byte or func() slog.Value.
+// Unqualified identifiers are resolved in the package of pkgPath and then in the universe.
+// Qualified identifiers are resolved by the package names among the analyzed package and its imports.
+// A type argument * matches any type, and a matcher of the instances is returned instead of the type in that case.
+func evalTypeExpr(pass *analysis.Pass, pkgPath, expr string) (types.Type, matcher, error) {
+	// replace twice for adjacent wildcards such as [*,*].
+	src := wildcardArg.ReplaceAllString(wildcardArg.ReplaceAllString(expr, "${1}_${2}"), "${1}_${2}")
+	e, err := parser.ParseExpr(src)
+	if err != nil {
+		return nil, nil, newErrInvalidTypeExpr(expr)
+	}
+	ev := &typeEvaluator{pass: pass, pkgPath: pkgPath, expr: expr}
+	switch e := astutil.Unparen(e).(type) {
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		// instance patterns are allowed only at the top level.
+		typ, p, err := ev.instance(e)
+		if p != nil {
+			return nil, p, err
+		}
+		return typ, nil, err
+	}
+	typ, err := ev.eval(e)
+	return typ, nil, err
+}
+
+type typeEvaluator struct {
+	pass    *analysis.Pass
+	pkgPath string
+	// expr is the whole expression for errors.
+	expr string
+}
+
+// eval returns the type denoted by e.
+func (ev *typeEvaluator) eval(e ast.Expr) (types.Type, error) {
+	switch e := e.(type) {
+	case *ast.ParenExpr:
+		return ev.eval(e.X)
+	case *ast.IndexExpr, *ast.IndexListExpr:
+		typ, p, err := ev.instance(e)
+		if err != nil {
+			return nil, err
+		}
+		if p != nil {
+			return nil, newErrInvalidTypeExpr(ev.expr)
+		}
+		return typ, nil
+	case *ast.Ident:
+		if e.Name == "_" {
+			// a wildcard is allowed only as a type argument.
+			return nil, newErrInvalidTypeExpr(ev.expr)
+		}
+		if ev.pkgPath != "" {
+			if obj, ok := analysisutil.ObjectOfBFS(ev.pass.Pkg, ev.pkgPath, e.Name).(*types.TypeName); ok {
+				return obj.Type(), nil
+			}
+		}
+		obj, ok := types.Universe.Lookup(e.Name).(*types.TypeName)
+		if !ok {
+			return nil, newErrIdentNotFound(ev.pass.Pkg.Path(), ev.pkgPath, e.Name)
+		}
+		return obj.Type(), nil
+	case *ast.SelectorExpr:
+		x, ok := e.X.(*ast.Ident)
+		if !ok {
+			return nil, newErrInvalidTypeExpr(ev.expr)
+		}
+		return ev.qualified(x.Name, e.Sel.Name)
+	case *ast.StarExpr:
+		elem, err := ev.eval(e.X)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewPointer(elem), nil
+	case *ast.ArrayType:
+		elem, err := ev.eval(e.Elt)
+		if err != nil {
+			return nil, err
+		}
+		if e.Len == nil {
+			return types.NewSlice(elem), nil
+		}
+		lit, ok := e.Len.(*ast.BasicLit)
+		if !ok || lit.Kind != token.INT {
+			return nil, newErrInvalidTypeExpr(ev.expr)
+		}
+		n, ok := constant.Int64Val(constant.MakeFromLiteral(lit.Value, lit.Kind, 0))
+		if !ok {
+			return nil, newErrInvalidTypeExpr(ev.expr)
+		}
+		return types.NewArray(elem, n), nil
+	case *ast.MapType:
+		key, err := ev.eval(e.Key)
+		if err != nil {
+			return nil, err
+		}
+		elem, err := ev.eval(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		return types.NewMap(key, elem), nil
+	case *ast.ChanType:
+		elem, err := ev.eval(e.Value)
+		if err != nil {
+			return nil, err
+		}
+		dir := types.SendRecv
+		switch e.Dir {
+		case ast.SEND:
+			dir = types.SendOnly
+		case ast.RECV:
+			dir = types.RecvOnly
+		}
+		return types.NewChan(dir, elem), nil
+	case *ast.FuncType:
+		return ev.signature(e)
+	case *ast.InterfaceType:
+		if len(e.Methods.List) > 0 {
+			// only the empty interface is supported.
+			return nil, newErrInvalidTypeExpr(ev.expr)
+		}
+		return types.NewInterfaceType(nil, nil).Complete(), nil
+	}
+	return nil, newErrInvalidTypeExpr(ev.expr)
+}
+
+// qualified returns the type name declared in the package named pkgName among the analyzed package and its imports.
+// It is an error if more than one of the packages named pkgName declare the type name.
+func (ev *typeEvaluator) qualified(pkgName, name string) (types.Type, error) {
+	pkgPath := pkgName
+	var found []*types.TypeName
+	analysisutil.WalkPackagesBFS(ev.pass.Pkg, func(p *types.Package) bool {
+		if p.Name() != pkgName {
+			return true
+		}
+		pkgPath = p.Path()
+		if obj, ok := p.Scope().Lookup(name).(*types.TypeName); ok {
+			found = append(found, obj)
+		}
+		return true
+	})
+	switch len(found) {
+	case 0:
+		return nil, newErrIdentNotFound(ev.pass.Pkg.Path(), pkgPath, name)
+	case 1:
+		return found[0].Type(), nil
+	}
+	return nil, newErrAmbiguousPkgName(ev.pass.Pkg.Path(), pkgName, name)
+}
+
+func (ev *typeEvaluator) signature(e *ast.FuncType) (*types.Signature, error) {
+	params, variadic, err := ev.tuple(e.Params)
+	if err != nil {
+		return nil, err
+	}
+	results, _, err := ev.tuple(e.Results)
+	if err != nil {
+		return nil, err
+	}
+	return types.NewSignatureType(nil, nil, nil, params, results, variadic), nil
+}
+
+// tuple returns the tuple of the field list. variadic is true if the last field is in the form ...T.
+func (ev *typeEvaluator) tuple(fields *ast.FieldList) (tuple *types.Tuple, variadic bool, err error) {
+	if fields == nil {
+		return types.NewTuple(), false, nil
+	}
+	var vars []*types.Var
+	for i, f := range fields.List {
+		typeExpr := f.Type
+		if ell, ok := typeExpr.(*ast.Ellipsis); ok {
+			if i != len(fields.List)-1 || len(f.Names) > 1 {
+				return nil, false, newErrInvalidTypeExpr(ev.expr)
+			}
+			typeExpr = &ast.ArrayType{Elt: ell.Elt}
+			variadic = true
+		}
+		typ, err := ev.eval(typeExpr)
+		if err != nil {
+			return nil, false, err
+		}
+		n := len(f.Names)
+		if n == 0 {
+			n = 1
+		}
+		for j := 0; j < n; j++ {
+			vars = append(vars, types.NewParam(token.NoPos, nil, "", typ))
+		}
+	}
+	return types.NewTuple(vars...), variadic, nil
+}
+
+// instance returns the instantiated type of the generic type expression e such as Option[int].
+// If any of the type arguments is a wildcard, the pattern matching the instances is returned instead.
+func (ev *typeEvaluator) instance(e ast.Expr) (types.Type, *instancePattern, error) {
+	var x ast.Expr
+	var indices []ast.Expr
+	switch e := e.(type) {
+	case *ast.IndexExpr:
+		x, indices = e.X, []ast.Expr{e.Index}
+	case *ast.IndexListExpr:
+		x, indices = e.X, e.Indices
+	}
+	typ, err := ev.eval(x)
+	if err != nil {
+		return nil, nil, err
+	}
+	named, ok := typ.(*types.Named)
+	if !ok || named.TypeParams().Len() != len(indices) {
+		return nil, nil, newErrInvalidTypeExpr(ev.expr)
+	}
+	args := make([]types.Type, 0, len(indices))
+	wildcard := false
+	for _, idx := range indices {
+		if id, ok := idx.(*ast.Ident); ok && id.Name == "_" {
+			args = append(args, nil)
+			wildcard = true
+			continue
+		}
+		arg, err := ev.eval(idx)
+		if err != nil {
+			return nil, nil, err
+		}
+		args = append(args, arg)
+	}
+	if wildcard {
+		return nil, &instancePattern{origin: named, args: args}, nil
+	}
+	inst, err := types.Instantiate(nil, named, args, true)
+	if err != nil {
+		return nil, nil, newErrInvalidTypeExpr(ev.expr)
+	}
+	return inst, nil, nil
+}
+
+// instancePattern matches the instances of a generic type.
+type instancePattern struct {
+	origin *types.Named
+	// args are the type arguments. nil matches any type.
+	args []types.Type
+}
+
+func (p *instancePattern) String() string {
+	ss := make([]string, 0, len(p.args))
+	for _, a := range p.args {
+		if a == nil {
+			ss = append(ss, "*")
+			continue
+		}
+		ss = append(ss, a.String())
+	}
+	return p.origin.String() + "[" + strings.Join(ss, ", ") + "]"
+}
+
+// Match reports whether typ is an instance of the generic type matching the type arguments.
+func (p *instancePattern) Match(typ types.Type) bool {
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.Origin() != p.origin || named.TypeArgs().Len() != len(p.args) {
+		return false
+	}
+	for i, a := range p.args {
+		if a != nil && !types.Identical(a, named.TypeArgs().At(i)) {
+			return false
+		}
+	}
+	return true
+}
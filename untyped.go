@@ -0,0 +1,104 @@
+package notany
+
+import (
+	"go/ast"
+	"go/constant"
+	"go/token"
+	"go/types"
+	"math"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// allowUntyped reports whether the untyped constant argument arg is representable as any of the allowed basic types of t
+// whose value constraints allow the value.
+func allowUntyped(pass *analysis.Pass, t *analysisTarget, arg callArg) bool {
+	if !isUntypedConst(pass, arg.Expr) {
+		return false
+	}
+	val := pass.TypesInfo.Types[arg.Expr].Value
+	for typ := range t.Allowed.Types {
+		b, ok := typ.(*types.Basic)
+		if !ok {
+			continue
+		}
+		if representable(pass, val, b) && t.AllowValue(b, val) {
+			return true
+		}
+	}
+	return false
+}
+
+// isUntypedConst reports whether expr is an untyped constant expression such as 1, "str", or 1 << 2.
+func isUntypedConst(pass *analysis.Pass, expr ast.Expr) bool {
+	if tv, ok := pass.TypesInfo.Types[expr]; !ok || tv.Value == nil {
+		return false
+	}
+	switch e := astutil.Unparen(expr).(type) {
+	case *ast.BasicLit:
+		return true
+	case *ast.Ident:
+		c, ok := pass.TypesInfo.ObjectOf(e).(*types.Const)
+		if !ok {
+			return false
+		}
+		b, ok := c.Type().(*types.Basic)
+		return ok && b.Info()&types.IsUntyped != 0
+	case *ast.SelectorExpr:
+		return isUntypedConst(pass, e.Sel)
+	case *ast.UnaryExpr:
+		return isUntypedConst(pass, e.X)
+	case *ast.BinaryExpr:
+		switch e.Op {
+		case token.EQL, token.NEQ, token.LSS, token.LEQ, token.GTR, token.GEQ:
+			// comparison of constants is an untyped boolean constant.
+			return true
+		case token.SHL, token.SHR:
+			return isUntypedConst(pass, e.X)
+		}
+		return isUntypedConst(pass, e.X) && isUntypedConst(pass, e.Y)
+	}
+	return false
+}
+
+// representable reports whether the constant val is representable as a value of the basic type b.
+func representable(pass *analysis.Pass, val constant.Value, b *types.Basic) bool {
+	info := b.Info()
+	switch {
+	case info&types.IsBoolean != 0:
+		return val.Kind() == constant.Bool
+	case info&types.IsString != 0:
+		return val.Kind() == constant.String
+	case info&types.IsInteger != 0:
+		x := constant.ToInt(val)
+		if x.Kind() != constant.Int {
+			return false
+		}
+		bits := uint(pass.TypesSizes.Sizeof(b) * 8)
+		if info&types.IsUnsigned != 0 {
+			if constant.Sign(x) < 0 {
+				return false
+			}
+			return constant.BitLen(x) <= int(bits)
+		}
+		// the range of signed integers is [-2^(bits-1), 2^(bits-1)-1].
+		lower := constant.Shift(constant.MakeInt64(-1), token.SHL, bits-1)
+		upper := constant.BinaryOp(constant.Shift(constant.MakeInt64(1), token.SHL, bits-1), token.SUB, constant.MakeInt64(1))
+		return constant.Compare(x, token.GEQ, lower) && constant.Compare(x, token.LEQ, upper)
+	case info&types.IsFloat != 0:
+		x := constant.ToFloat(val)
+		if x.Kind() != constant.Float && x.Kind() != constant.Int {
+			return false
+		}
+		f, _ := constant.Float64Val(x)
+		if b.Kind() == types.Float32 {
+			return !math.IsInf(float64(float32(f)), 0)
+		}
+		return !math.IsInf(f, 0)
+	case info&types.IsComplex != 0:
+		x := constant.ToComplex(val)
+		return x.Kind() == constant.Complex || x.Kind() == constant.Float || x.Kind() == constant.Int
+	}
+	return false
+}
@@ -0,0 +1,206 @@
+package notany
+
+import (
+	"go/constant"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"github.com/qawatake/notany/internal/analysisutil"
+	"golang.org/x/tools/go/analysis"
+)
+
+// Values represents constraints on the constant values of an allowed type.
+// A value is allowed if it satisfies any of the constraints.
+// The constraints are checked for elements, sent values and assigned values as well as arguments.
+type Values struct {
+	// List of allowed values written as Go constant expressions such as `1`, `"debug"`, or `true`.
+	Consts []string
+	// The path of the package whose constants are allowed values.
+	// Only the constants of the allowed type are considered.
+	GroupPkgPath string
+	// The prefix of the names of the constants in the package of GroupPkgPath.
+	// If it is empty, all the constants are considered.
+	GroupPrefix string
+	// The inclusive range of allowed numeric values written as Go constant expressions.
+	// If Min (or Max) is empty, the range is not bounded below (or above).
+	Min string
+	Max string
+	// NonConst determines how an argument which is not a compile-time constant is handled.
+	NonConst NonConstPolicy
+}
+
+// NonConstPolicy determines how an argument which is not a compile-time constant is handled.
+type NonConstPolicy int
+
+const (
+	// NonConstReject reports the argument.
+	NonConstReject NonConstPolicy = iota
+	// NonConstAccept accepts the argument.
+	NonConstAccept
+)
+
+type valueConstraint struct {
+	// types is the types allowed by the entry of the constraint.
+	types *allowedTypes
+	// unconstrained is true if the entry has no constraints on the values.
+	unconstrained bool
+	consts        []constant.Value
+	min           constant.Value
+	max           constant.Value
+	ranged        bool
+	nonConst      NonConstPolicy
+}
+
+// toValueConstraints returns the value constraints for the entries of the list.
+// The entries without Values are also returned as unconstrained ones, because a value is allowed if any of the entries allowing its type allows it.
+// If none of the entries has Values, nil is returned.
+func toValueConstraints(pass *analysis.Pass, list []Allowed) ([]*valueConstraint, error) {
+	constrained := false
+	for _, a := range list {
+		constrained = constrained || a.Values != nil
+	}
+	if !constrained {
+		return nil, nil
+	}
+	ret := make([]*valueConstraint, 0, len(list))
+	for _, a := range list {
+		typs, err := toAllowedTypes(pass, []Allowed{a})
+		if err != nil {
+			return nil, err
+		}
+		if a.Values == nil {
+			ret = append(ret, &valueConstraint{types: typs, unconstrained: true})
+			continue
+		}
+		vc, err := newValueConstraint(pass, a, typs)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, vc)
+	}
+	return ret, nil
+}
+
+func newValueConstraint(pass *analysis.Pass, a Allowed, typs *allowedTypes) (*valueConstraint, error) {
+	vc := &valueConstraint{
+		types:    typs,
+		nonConst: a.Values.NonConst,
+	}
+	for _, c := range a.Values.Consts {
+		val, err := evalConst(c)
+		if err != nil {
+			return nil, err
+		}
+		vc.consts = append(vc.consts, val)
+	}
+	if a.Values.GroupPkgPath != "" {
+		pkg := analysisutil.PackageOfBFS(pass.Pkg, a.Values.GroupPkgPath)
+		if pkg == nil {
+			return nil, newErrIdentNotFound(pass.Pkg.Path(), a.Values.GroupPkgPath, a.Values.GroupPrefix+"*")
+		}
+		for _, name := range pkg.Scope().Names() {
+			c, ok := pkg.Scope().Lookup(name).(*types.Const)
+			if !ok || !strings.HasPrefix(name, a.Values.GroupPrefix) {
+				continue
+			}
+			if !typs.Contains(c.Type()) && !isUntypedOf(c.Type(), typs) {
+				continue
+			}
+			vc.consts = append(vc.consts, c.Val())
+		}
+	}
+	if a.Values.Min != "" {
+		val, err := evalRealConst(a.Values.Min)
+		if err != nil {
+			return nil, err
+		}
+		vc.min = val
+		vc.ranged = true
+	}
+	if a.Values.Max != "" {
+		val, err := evalRealConst(a.Values.Max)
+		if err != nil {
+			return nil, err
+		}
+		vc.max = val
+		vc.ranged = true
+	}
+	return vc, nil
+}
+
+// isUntypedOf reports whether typ is an untyped basic type whose default type is in typs.
+func isUntypedOf(typ types.Type, typs *allowedTypes) bool {
+	b, ok := typ.(*types.Basic)
+	if !ok || b.Info()&types.IsUntyped == 0 {
+		return false
+	}
+	return typs.Contains(types.Default(b))
+}
+
+func evalConst(expr string) (constant.Value, error) {
+	tv, err := types.Eval(token.NewFileSet(), nil, token.NoPos, expr)
+	if err != nil || tv.Value == nil {
+		return nil, newErrInvalidConst(expr)
+	}
+	return tv.Value, nil
+}
+
+func evalRealConst(expr string) (constant.Value, error) {
+	val, err := evalConst(expr)
+	if err != nil {
+		return nil, err
+	}
+	if !isReal(val) {
+		return nil, newErrInvalidConst(expr)
+	}
+	return val, nil
+}
+
+// Allow reports whether the constant value val is allowed.
+// val is nil if the argument is not a constant.
+func (vc *valueConstraint) Allow(val constant.Value) bool {
+	if vc.unconstrained {
+		return true
+	}
+	if val == nil {
+		return vc.nonConst == NonConstAccept
+	}
+	for _, c := range vc.consts {
+		if constEqual(c, val) {
+			return true
+		}
+	}
+	if vc.ranged && isReal(val) {
+		if vc.min != nil && constant.Compare(val, token.LSS, vc.min) {
+			return false
+		}
+		if vc.max != nil && constant.Compare(val, token.GTR, vc.max) {
+			return false
+		}
+		return true
+	}
+	return false
+}
+
+func constEqual(x, y constant.Value) bool {
+	if isNumeric(x) && isNumeric(y) {
+		return constant.Compare(x, token.EQL, y)
+	}
+	if x.Kind() != y.Kind() || x.Kind() == constant.Unknown {
+		return false
+	}
+	return constant.Compare(x, token.EQL, y)
+}
+
+func isNumeric(val constant.Value) bool {
+	switch val.Kind() {
+	case constant.Int, constant.Float, constant.Complex:
+		return true
+	}
+	return false
+}
+
+func isReal(val constant.Value) bool {
+	return val.Kind() == constant.Int || val.Kind() == constant.Float
+}
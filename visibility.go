@@ -0,0 +1,51 @@
+package notany
+
+import (
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+)
+
+// visible reports whether the named type of typ satisfies the visibility predicate p relative to the caller package.
+// The named type is typ itself or the type pointed to by typ.
+func (a *analysisTarget) visible(p Predicate, typ types.Type) bool {
+	if ptr, ok := types.Unalias(typ).(*types.Pointer); ok {
+		typ = ptr.Elem()
+	}
+	typ = types.Unalias(typ)
+	var obj *types.TypeName
+	switch t := typ.(type) {
+	case *types.Named:
+		obj = t.Obj()
+	case *types.Basic:
+		// predeclared types are accessible from any package.
+		return p == PredicateExported
+	default:
+		return false
+	}
+	switch p {
+	case PredicateCallerPackage:
+		return obj.Pkg() != nil && obj.Pkg() == a.Caller
+	case PredicateCallerModule:
+		return obj.Pkg() != nil && inModule(obj.Pkg().Path(), a.ModulePath)
+	case PredicateUnexported:
+		return obj.Pkg() != nil && !obj.Exported()
+	case PredicateExported:
+		return obj.Pkg() == nil || obj.Exported()
+	}
+	return true
+}
+
+func inModule(pkgPath, modulePath string) bool {
+	return pkgPath == modulePath || strings.HasPrefix(pkgPath, modulePath+"/")
+}
+
+// modulePathOf returns the path of the module which the package of pass belongs to.
+// If the module is unknown, the package path is returned.
+func modulePathOf(pass *analysis.Pass) string {
+	if pass.Module != nil && pass.Module.Path != "" {
+		return pass.Module.Path
+	}
+	return pass.Pkg.Path()
+}
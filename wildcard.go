@@ -0,0 +1,63 @@
+package notany
+
+import (
+	"go/types"
+	"strings"
+)
+
+const (
+	// wildcardTypeName matches any named type declared in the package.
+	wildcardTypeName = "*"
+	// wildcardPointerTypeName matches the pointer to any named type declared in the package.
+	wildcardPointerTypeName = "*.*"
+)
+
+// packagePattern matches the named types declared in the packages.
+type packagePattern struct {
+	// path is the package path. If it ends with /..., the subpackages also match.
+	path    string
+	pointer bool
+}
+
+// newPackagePattern returns the pattern of the wildcard type name of a.
+// The package path is required because the builtin types are not declared in any package.
+func newPackagePattern(a Allowed) (*packagePattern, error) {
+	if strings.TrimSuffix(a.PkgPath, "/...") == "" {
+		return nil, newErrWildcardWithoutPkgPath(a.PkgPath, a.TypeName)
+	}
+	return &packagePattern{
+		path:    a.PkgPath,
+		pointer: a.TypeName == wildcardPointerTypeName,
+	}, nil
+}
+
+func (p *packagePattern) String() string {
+	if p.pointer {
+		return "*" + p.path + ".*"
+	}
+	return p.path + ".*"
+}
+
+// Match reports whether typ is a named type declared in the packages, or the pointer to it if p.pointer is true.
+func (p *packagePattern) Match(typ types.Type) bool {
+	if p.pointer {
+		ptr, ok := types.Unalias(typ).(*types.Pointer)
+		if !ok {
+			return false
+		}
+		typ = ptr.Elem()
+	}
+	named, ok := types.Unalias(typ).(*types.Named)
+	if !ok || named.Obj().Pkg() == nil {
+		return false
+	}
+	return matchPackagePath(p.path, named.Obj().Pkg().Path())
+}
+
+// matchPackagePath reports whether path matches pattern, which may end with /... to match the subpackages.
+func matchPackagePath(pattern, path string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, "/..."); ok {
+		return path == prefix || strings.HasPrefix(path, prefix+"/")
+	}
+	return path == pattern
+}
@@ -0,0 +1,322 @@
+package notany
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"strings"
+
+	"golang.org/x/tools/go/analysis"
+	"golang.org/x/tools/go/ssa"
+)
+
+// wrapperFact is exported for a function whose parameters flow unchanged into targeted arguments.
+type wrapperFact struct {
+	Params []wrappedParam
+}
+
+// wrappedParam is a parameter of a wrapper function which inherits the policy of a target.
+type wrappedParam struct {
+	ArgPos     int
+	Allowed    []Allowed
+	Predicates []Predicate
+	MaxSize    int64
+	AllowNil   bool
+	Untyped    UntypedPolicy
+	Interface  InterfacePolicy
+	Untraced   UntracedPolicy
+	ConstOnly  bool
+	Flow       bool
+	AllowedFor bool
+	// Wrapped is the target whose policy is inherited.
+	Wrapped allowedFor
+}
+
+func (*wrapperFact) AFact() {}
+
+func (f *wrapperFact) String() string {
+	ss := make([]string, 0, len(f.Params))
+	for _, p := range f.Params {
+		names := make([]string, 0, len(p.Allowed))
+		for _, a := range p.Allowed {
+			if a.PkgPath == "" {
+				names = append(names, a.TypeName)
+				continue
+			}
+			names = append(names, a.PkgPath+"."+a.TypeName)
+		}
+		for _, pred := range p.Predicates {
+			names = append(names, pred.String())
+		}
+		if p.MaxSize > 0 {
+			names = append(names, fmt.Sprintf("size<=%d", p.MaxSize))
+		}
+		if p.AllowNil {
+			names = append(names, "nil")
+		}
+		if p.ConstOnly {
+			names = append(names, "const")
+		}
+		if p.AllowedFor {
+			names = append(names, "allowed-for("+p.Wrapped.String()+")")
+		}
+		ss = append(ss, fmt.Sprintf("%d:[%s]", p.ArgPos, strings.Join(names, " ")))
+	}
+	return "wrapper(" + strings.Join(ss, ", ") + ")"
+}
+
+// factTargets returns the targets inferred for wrapper functions in the imported packages.
+func factTargets(pass *analysis.Pass, modulePath string) []*analysisTarget {
+	var ret []*analysisTarget
+	for _, f := range pass.AllObjectFacts() {
+		wf, ok := f.Fact.(*wrapperFact)
+		if !ok {
+			continue
+		}
+		fn, ok := f.Object.(*types.Func)
+		if !ok {
+			continue
+		}
+		for _, p := range wf.Params {
+			p := p
+			var list []Allowed
+			for _, a := range p.Allowed {
+				// entries not resolvable from the package cannot be passed, so they are ignored one by one.
+				if _, err := toAllowedTypes(pass, []Allowed{a}); err != nil {
+					continue
+				}
+				if _, err := toValueConstraints(pass, []Allowed{a}); err != nil {
+					continue
+				}
+				list = append(list, a)
+			}
+			allowed, err := toAllowedTypes(pass, list)
+			if err != nil {
+				continue
+			}
+			values, err := toValueConstraints(pass, list)
+			if err != nil {
+				continue
+			}
+			ret = append(ret, &analysisTarget{
+				Func:        fn,
+				ArgPos:      p.ArgPos,
+				Wrappers:    true,
+				Predicates:  p.Predicates,
+				MaxSize:     p.MaxSize,
+				AllowNil:    p.AllowNil,
+				Untyped:     p.Untyped,
+				Interface:   p.Interface,
+				Untraced:    p.Untraced,
+				ConstOnly:   p.ConstOnly,
+				Flow:        p.Flow,
+				AllowedFor:  p.AllowedFor,
+				Wrapped:     &p.Wrapped,
+				Sizes:       pass.TypesSizes,
+				Caller:      pass.Pkg,
+				ModulePath:  modulePath,
+				Allowed:     allowed,
+				Values:      values,
+				AllowedList: p.Allowed,
+			})
+		}
+	}
+	return ret
+}
+
+type forwarding struct {
+	Lparen token.Pos
+	Target *analysisTarget
+}
+
+// InferWrappers returns the targets for functions in the package whose parameters flow unchanged into the arguments of targets.
+// The inferred targets are exported as facts.
+func (f *flow) InferWrappers(targets []*analysisTarget) []*analysisTarget {
+	f.forwarded = make(map[forwarding]struct{})
+	byFunc := make(map[*types.Func][]*analysisTarget)
+	for _, t := range targets {
+		if t.Func == nil || !t.Wrappers {
+			continue
+		}
+		if _, ok := t.Func.Type().(*types.Signature); !ok {
+			continue
+		}
+		byFunc[t.Func] = append(byFunc[t.Func], t)
+	}
+
+	if len(byFunc) == 0 {
+		// the SSA form is not needed.
+		return nil
+	}
+	var wrappers []*analysisTarget
+	// iterate until wrappers of wrappers are inferred.
+	for changed := true; changed; {
+		changed = false
+		for _, fn := range f.Funcs() {
+			obj, ok := fn.Object().(*types.Func)
+			if !ok {
+				continue
+			}
+			for _, b := range fn.Blocks {
+				for _, instr := range b.Instrs {
+					call, ok := instr.(ssa.CallInstruction)
+					if !ok {
+						continue
+					}
+					callee := call.Common().StaticCallee()
+					if callee == nil {
+						continue
+					}
+					cobj, ok := callee.Object().(*types.Func)
+					if !ok {
+						continue
+					}
+					for _, t := range byFunc[cobj] {
+						key := forwarding{Lparen: call.Common().Pos(), Target: t}
+						if _, ok := f.forwarded[key]; ok {
+							continue
+						}
+						pos, ok := forwardedParam(fn, call.Common(), t, byFunc)
+						if !ok {
+							continue
+						}
+						f.forwarded[key] = struct{}{}
+						// the wrapper inherits the whole policy of the target.
+						w := new(analysisTarget)
+						*w = *t
+						w.Func = obj
+						w.ArgPos = pos
+						w.Param = ParamAuto
+						if w.Wrapped == nil {
+							w.Wrapped = allowedForOf(t.Func, t.ArgPos)
+						}
+						byFunc[obj] = append(byFunc[obj], w)
+						wrappers = append(wrappers, w)
+						changed = true
+					}
+				}
+			}
+		}
+	}
+
+	facts := make(map[*types.Func]*wrapperFact)
+	for _, w := range wrappers {
+		if facts[w.Func] == nil {
+			facts[w.Func] = new(wrapperFact)
+		}
+		facts[w.Func].Params = append(facts[w.Func].Params, wrappedParam{
+			ArgPos:     w.ArgPos,
+			Allowed:    w.AllowedList,
+			Predicates: w.Predicates,
+			MaxSize:    w.MaxSize,
+			AllowNil:   w.AllowNil,
+			Untyped:    w.Untyped,
+			Interface:  w.Interface,
+			Untraced:   w.Untraced,
+			ConstOnly:  w.ConstOnly,
+			Flow:       w.Flow,
+			AllowedFor: w.AllowedFor,
+			Wrapped:    *w.Wrapped,
+		})
+	}
+	for fn, fact := range facts {
+		f.pass.ExportObjectFact(fn, fact)
+	}
+	return wrappers
+}
+
+// Forwarded reports whether the argument of the call n checked by t is a parameter forwarded by a wrapper function.
+func (f *flow) Forwarded(n *ast.CallExpr, t *analysisTarget) bool {
+	_, ok := f.forwarded[forwarding{Lparen: n.Lparen, Target: t}]
+	return ok
+}
+
+// forwardedParam returns the position of the parameter of fn which flows unchanged into the argument of the call checked by t.
+// If the parameter of t is variadic, the variadic parameter of fn must be spread unchanged into it (args...).
+func forwardedParam(fn *ssa.Function, call *ssa.CallCommon, t *analysisTarget, targets map[*types.Func][]*analysisTarget) (int, bool) {
+	sig, ok := t.Func.Type().(*types.Signature)
+	if !ok {
+		return 0, false
+	}
+	offset := len(call.Args) - sig.Params().Len()
+	if offset < 0 || offset+t.ArgPos >= len(call.Args) {
+		return 0, false
+	}
+	variadic := isVariadicParam(sig, t.ArgPos)
+	arg := call.Args[offset+t.ArgPos]
+	for {
+		ci, ok := arg.(*ssa.ChangeInterface)
+		if !ok {
+			break
+		}
+		arg = ci.X
+	}
+	p, ok := arg.(*ssa.Parameter)
+	if !ok {
+		return 0, false
+	}
+	// the receiver is the first parameter of methods.
+	recv := 0
+	if fn.Signature.Recv() != nil {
+		recv = 1
+	}
+	for i, param := range fn.Params {
+		if param != p {
+			continue
+		}
+		pos := i - recv
+		if pos < 0 || isVariadicParam(fn.Signature, pos) != variadic {
+			return 0, false
+		}
+		if variadic {
+			if !unmodified(p, targets) {
+				return 0, false
+			}
+			return pos, true
+		}
+		if !types.IsInterface(p.Type()) {
+			return 0, false
+		}
+		return pos, true
+	}
+	return 0, false
+}
+
+// unmodified reports whether the elements of the variadic parameter p are neither modified nor leaked in the function.
+// p may only be spread into the calls of targets or passed to len and cap.
+func unmodified(p *ssa.Parameter, targets map[*types.Func][]*analysisTarget) bool {
+	refs := p.Referrers()
+	if refs == nil {
+		return true
+	}
+	for _, ref := range *refs {
+		switch ref := ref.(type) {
+		case *ssa.DebugRef:
+			continue
+		case ssa.CallInstruction:
+			common := ref.Common()
+			if b, ok := common.Value.(*ssa.Builtin); ok && (b.Name() == "len" || b.Name() == "cap") {
+				continue
+			}
+			callee := common.StaticCallee()
+			if callee == nil {
+				break
+			}
+			if obj, ok := callee.Object().(*types.Func); !ok || len(targets[obj]) == 0 {
+				break
+			}
+			if common.Signature().Variadic() && len(common.Args) > 0 && common.Args[len(common.Args)-1] == p {
+				found := false
+				for _, a := range common.Args[:len(common.Args)-1] {
+					found = found || a == p
+				}
+				if !found {
+					continue
+				}
+			}
+		}
+		return false
+	}
+	return true
+}